@@ -67,7 +67,7 @@ var cleanCmd = &cobra.Command{
 			return err
 		}
 
-		return cleanDatabase(tables, d)
+		return cleanDatabase(DB, tables, d)
 	},
 }
 
@@ -76,10 +76,10 @@ func init() {
 }
 
 // cleanDatabase truncates tables in reverse order.
-func cleanDatabase(tables []*schema.Table, d dialect.Dialect) error {
+func cleanDatabase(db *sql.DB, tables []*schema.Table, d dialect.Dialect) error {
 	log.Println("Disabling Foreign Key Checks...")
 
-	tx, err := DB.Begin()
+	tx, err := db.Begin()
 	if err != nil {
 		return err
 	}
@@ -93,7 +93,7 @@ func cleanDatabase(tables []*schema.Table, d dialect.Dialect) error {
 		log.Printf("Warning: Failed to execute BeforePump hook: %v. Continuing...\n", err)
 		if _, ok := d.(*dialect.PostgresDialect); ok {
 			tx.Rollback()
-			tx, _ = DB.Begin()
+			tx, _ = db.Begin()
 		}
 	}
 
@@ -106,7 +106,7 @@ func cleanDatabase(tables []*schema.Table, d dialect.Dialect) error {
 		// MSSQL: Use DELETE instead of TRUNCATE to avoid FK issues
 		var query string
 		if _, ok := d.(*dialect.MSSQLDialect); ok {
-			query = fmt.Sprintf("DELETE FROM %s", table.Name)
+			query = fmt.Sprintf("DELETE FROM %s", d.QuoteIdent(table.Name))
 		} else {
 			query = d.TruncateQuery(table.Name)
 		}
@@ -115,8 +115,8 @@ func cleanDatabase(tables []*schema.Table, d dialect.Dialect) error {
 		}
 
 		// MSSQL: Reset IDENTITY seed after DELETE
-		if _, ok := d.(*dialect.MSSQLDialect); ok {
-			resetQuery := fmt.Sprintf("DBCC CHECKIDENT ('%s', RESEED, 0)", table.Name)
+		if ms, ok := d.(*dialect.MSSQLDialect); ok {
+			resetQuery := ms.ReseedIdentityQuery(table.Name)
 			if _, err := tx.Exec(resetQuery); err != nil {
 				log.Printf("Warning: Failed to reset IDENTITY for %s: %v (continuing...)\n", table.Name, err)
 			}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -40,3 +41,18 @@ func GetActiveDBConfig() (*DBConfig, error) {
 
 	return activeConfig, nil
 }
+
+// GetDBConfig returns the database configuration with the given name (case-insensitive).
+func GetDBConfig(name string) (*DBConfig, error) {
+	var configs []DBConfig
+
+	if err := viper.UnmarshalKey("databases", &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse databases config: %w", err)
+	}
+	for i := range configs {
+		if strings.EqualFold(configs[i].Name, name) {
+			return &configs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no database named %q found in config", name)
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"db-pump/internal/engine"
+
+	"github.com/spf13/viper"
+)
+
+// sourceConfig returns the active database, or the one given by --dsn/--driver.
+func sourceConfig() (DBConfig, error) {
+	activeConfig, err := GetActiveDBConfig()
+	if err == nil {
+		return *activeConfig, nil
+	}
+	if DriverName == "" {
+		return DBConfig{}, fmt.Errorf("could not determine driver: ensure config file exists or use --dsn and --driver flags")
+	}
+	return DBConfig{Name: "CLI Wrapper", Driver: DriverName, DSN: dsn, Active: true}, nil
+}
+
+// targetConfig resolves the database written by mask/subset: a databases entry by
+// name, or a DSN with an optional driver.
+func targetConfig(name, targetDSN, targetDriver string) (DBConfig, error) {
+	if name != "" {
+		c, err := GetDBConfig(name)
+		if err != nil {
+			return DBConfig{}, err
+		}
+		return *c, nil
+	}
+	if targetDSN == "" {
+		return DBConfig{}, fmt.Errorf("a target is required: use --target <database name> or --target-dsn")
+	}
+	if targetDriver == "" {
+		targetDriver = detectDriver(targetDSN)
+		if targetDriver == "" {
+			return DBConfig{}, fmt.Errorf("could not detect driver from target DSN, please specify --target-driver")
+		}
+	}
+	return DBConfig{Name: "CLI Target", Driver: targetDriver, DSN: targetDSN}, nil
+}
+
+func detectDriver(connStr string) string {
+	for _, d := range []string{"postgres", "mysql", "sqlserver", "oracle"} {
+		if strings.Contains(connStr, d) {
+			return d
+		}
+	}
+	return ""
+}
+
+// openDB connects and returns the schema to analyze.
+func openDB(config DBConfig) (*sql.DB, string, error) {
+	db, err := sql.Open(config.Driver, config.DSN)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open %s: %w", config.Name, err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, "", fmt.Errorf("failed to connect to %s: %w", config.Name, err)
+	}
+	var schemaName string
+	if config.Driver == "mysql" {
+		db.QueryRow("SELECT DATABASE()").Scan(&schemaName)
+	} else if config.Driver == "sqlserver" || config.Driver == "mssql" {
+		schemaName = "dbo"
+	} else {
+		schemaName = "public"
+	}
+	return db, schemaName, nil
+}
+
+// loadSettings reads settings.* and the dictionaries and text models the generators depend on.
+func loadSettings() error {
+	if err := viper.UnmarshalKey("settings", &engine.Settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+	if _, ok := engine.LookupLocale(engine.Settings.Language); !ok {
+		return fmt.Errorf("unsupported settings.language %q (supported: ko, en, ja, zh)", engine.Settings.Language)
+	}
+	if err := engine.ValidatePasswordAlgorithms(engine.Settings.Password); err != nil {
+		return fmt.Errorf("settings.password: %w", err)
+	}
+	if err := engine.ValidateSeasonality(engine.Settings.Seasonality); err != nil {
+		return fmt.Errorf("settings.seasonality: %w", err)
+	}
+	if err := engine.LoadDictionaries(engine.Settings.Dictionaries); err != nil {
+		return fmt.Errorf("failed to load dictionaries: %w", err)
+	}
+	if err := engine.LoadTextModels(engine.Settings.Text); err != nil {
+		return fmt.Errorf("failed to load text models: %w", err)
+	}
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	"db-pump/internal/dialect" // Import
@@ -17,10 +16,11 @@ import (
 )
 
 var (
-	count  int
-	clean  bool
-	dryRun bool
-	tables []string
+	count   int
+	clean   bool
+	dryRun  bool
+	tables  []string
+	profile string
 )
 
 var fillCmd = &cobra.Command{
@@ -105,6 +105,17 @@ var fillCmd = &cobra.Command{
 		d := dialect.GetDialect(DriverName)
 		log.Printf("Using Dialect: %s\n", DriverName)
 
+		// Generation settings (settings.* in db-pump.yaml)
+		if err := loadSettings(); err != nil {
+			return err
+		}
+		if profile != "" { // Flag override
+			engine.Settings.Profile.File = profile
+		}
+		if err := engine.LoadProfile(engine.Settings.Profile.File); err != nil {
+			return fmt.Errorf("failed to load profile: %w", err)
+		}
+
 		// 1. Analyze
 		log.Println("Analyzing schema...")
 		allTables, err := schema.Analyze(DB, d, SchemaName)
@@ -129,31 +140,14 @@ var fillCmd = &cobra.Command{
 			}
 		}
 
-		var targetTables []*schema.Table
-		if len(targetTableNames) > 0 {
-			// Create a map for requested tables for O(1) lookup
-			reqTables := make(map[string]bool)
-			for _, t := range targetTableNames {
-				reqTables[strings.ToLower(t)] = true // Use strings
-			}
-
-			// Filter
-			for _, t := range allTables {
-				if reqTables[strings.ToLower(t.Name)] {
-					targetTables = append(targetTables, t)
-				}
-			}
-
-			if len(targetTables) == 0 {
-				return fmt.Errorf("no matching tables found for inputs: %v", targetTableNames)
-			}
-		} else {
-			targetTables = allTables
+		targetTables, err := selectTables(allTables, targetTableNames)
+		if err != nil {
+			return err
 		}
 
 		// Clean if requested
 		if clean {
-			if err := cleanDatabase(targetTables, d); err != nil {
+			if err := cleanDatabase(DB, targetTables, d); err != nil {
 				return err
 			}
 		}
@@ -190,7 +184,7 @@ var fillCmd = &cobra.Command{
 		}
 
 		// 4. Verification Step
-		verifiedResults := engine.VerifyInjection(DB, results)
+		verifiedResults := engine.VerifyInjection(DB, d, results)
 
 		elapsed := time.Since(start)
 
@@ -231,6 +225,7 @@ func init() {
 	fillCmd.Flags().BoolVar(&clean, "clean", false, "Clean tables before filling")
 	fillCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Simulate the process without writing to DB")
 	fillCmd.Flags().StringSliceVarP(&tables, "tables", "t", []string{}, "Specific tables to fill (comma-separated)")
+	fillCmd.Flags().StringVar(&profile, "profile", "", "Profile file from 'db-pump profile' to follow (overrides config)")
 
 	viper.BindPFlag("settings.default_count", fillCmd.Flags().Lookup("count"))
 	viper.SetDefault("settings.default_count", 100)
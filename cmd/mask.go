@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/engine"
+	"db-pump/internal/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	maskTarget       string
+	maskTargetDSN    string
+	maskTargetDriver string
+	maskTables       []string
+	maskClean        bool
+	maskDryRun       bool
+	maskSalt         string
+)
+
+var maskCmd = &cobra.Command{
+	Use:   "mask",
+	Short: "Copy real rows to another database with PII columns anonymized",
+	Long: `Reads every table of the source database (the active one, or --dsn) and writes
+the rows to a target database with the same schema, in dependency order.
+
+PII columns (names, phone numbers, emails, addresses, identifiers, passwords) are
+detected from column names and comments and replaced with consistent fake values;
+settings.mask.rules can choose another action per column: fake, hash
+(format-preserving), null, shuffle or keep.
+
+The same value always masks to the same output, so FK columns keep pointing at
+their (masked) parent rows. Use --dry-run to review the action of every column.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srcConfig, err := sourceConfig()
+		if err != nil {
+			return err
+		}
+		dstConfig, err := targetConfig(maskTarget, maskTargetDSN, maskTargetDriver)
+		if err != nil {
+			return err
+		}
+		if srcConfig.DSN == dstConfig.DSN {
+			return fmt.Errorf("source and target are the same database")
+		}
+		if err := loadSettings(); err != nil {
+			return err
+		}
+		if maskSalt != "" { // Flag override
+			engine.Settings.Mask.Salt = maskSalt
+		}
+		if !maskDryRun {
+			if err := engine.ValidateMaskSalt(engine.Settings.Mask); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("🦅 Masking %s (%s) → %s (%s)\n", srcConfig.Name, srcConfig.Driver, dstConfig.Name, dstConfig.Driver)
+
+		src, srcSchema, err := openDB(srcConfig)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		srcD := dialect.GetDialect(srcConfig.Driver)
+
+		log.Println("Analyzing schema...")
+		allTables, err := schema.Analyze(src, srcD, srcSchema)
+		if err != nil {
+			return err
+		}
+		targetTables, err := selectTables(allTables, maskTables)
+		if err != nil {
+			return err
+		}
+
+		m := engine.NewMasker(allTables)
+		if maskDryRun {
+			printMaskPlan(m, targetTables)
+			return nil
+		}
+
+		dst, dstSchema, err := openDB(dstConfig)
+		if err != nil {
+			return err
+		}
+		defer dst.Close()
+		dstD := dialect.GetDialect(dstConfig.Driver)
+
+		targetTables, err = existingTables(dst, dstD, dstSchema, targetTables)
+		if err != nil {
+			return err
+		}
+		if maskClean {
+			if err := cleanDatabase(dst, targetTables, dstD); err != nil {
+				return err
+			}
+		}
+
+		start := time.Now()
+		done := 0
+		results, err := engine.Copy(engine.NewDBRowSource(src, srcD), dst, dstD, targetTables, m, func(table string) {
+			done++
+			log.Printf("Masked %d/%d: %s", done, len(targetTables), table)
+		})
+		if err != nil {
+			return err
+		}
+		printCopyReport(results)
+		log.Printf("Mask Done! Time Elapsed: %s", time.Since(start))
+		return nil
+	},
+}
+
+// printMaskPlan lists the columns that are not copied as is.
+func printMaskPlan(m *engine.Masker, tables []*schema.Table) {
+	fmt.Println("🔍 Mask Plan:")
+	for i, t := range tables {
+		var masked []string
+		for _, c := range t.Columns {
+			if a := m.Action(t, c); a != engine.MaskKeep {
+				masked = append(masked, fmt.Sprintf("%s=%s", c.Name, a))
+			}
+		}
+		fmt.Printf("[%02d] %-20s : %s\n", i+1, t.Name, strings.Join(masked, ", "))
+	}
+}
+
+// existingTables drops (with a warning) the tables the target database doesn't have.
+func existingTables(db *sql.DB, d dialect.Dialect, schemaName string, tables []*schema.Table) ([]*schema.Table, error) {
+	log.Println("Analyzing target schema...")
+	targetTables, err := schema.Analyze(db, d, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	exists := make(map[string]bool)
+	for _, t := range targetTables {
+		exists[strings.ToLower(t.Name)] = true
+	}
+	var kept []*schema.Table
+	for _, t := range tables {
+		if exists[strings.ToLower(t.Name)] {
+			kept = append(kept, t)
+		} else {
+			log.Printf("Warning: table %s does not exist in the target, skipping", t.Name)
+		}
+	}
+	return kept, nil
+}
+
+// printCopyReport prints the rows read and written per table.
+func printCopyReport(results []schema.PumpResult) {
+	fmt.Println("\n📊 Summary Report (Dependency Order):")
+	total := 0
+	for i, r := range results {
+		icon := "✓"
+		if r.Status != "OK" {
+			icon = "!"
+		}
+		fmt.Printf("[%s] [%02d/%02d] %-20s : %d rows (Source: %d) - %s\n",
+			icon, i+1, len(results), r.TableName, r.Actual, r.Target, r.Status)
+		if r.ErrorMsg != "" {
+			fmt.Printf("    └ Error: %s\n", r.ErrorMsg)
+		}
+		total += r.Actual
+	}
+	fmt.Println("--------------------------------------------------")
+	fmt.Printf("Total Rows Copied: %d\n", total)
+}
+
+func init() {
+	RootCmd.AddCommand(maskCmd)
+
+	maskCmd.Flags().StringVar(&maskTarget, "target", "", "Target database: name of an entry under databases")
+	maskCmd.Flags().StringVar(&maskTargetDSN, "target-dsn", "", "Target database DSN (instead of --target)")
+	maskCmd.Flags().StringVar(&maskTargetDriver, "target-driver", "", "Target database driver (default: detected from --target-dsn)")
+	maskCmd.Flags().StringSliceVarP(&maskTables, "tables", "t", []string{}, "Specific tables to copy (comma-separated)")
+	maskCmd.Flags().BoolVar(&maskClean, "clean", false, "Clean target tables before copying")
+	maskCmd.Flags().BoolVar(&maskDryRun, "dry-run", false, "Print the action of every masked column without copying")
+	maskCmd.Flags().StringVar(&maskSalt, "salt", "", "Secret that keys hashes and fake values (overrides settings.mask.salt)")
+}
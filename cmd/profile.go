@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/engine"
+	"db-pump/internal/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileOutput      string
+	profileTables      []string
+	profileSample      int
+	profileTopK        int
+	profileMinCount    int
+	profileMaxDistinct int
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Record value distributions of an existing database for fill",
+	Long: `Samples every table of the connected database (SELECT only, so a read-only
+replica is fine) and writes per-column statistics: NULL ratio, distinct count,
+frequent values, numeric/date histograms, string lengths and FK fan-out.
+Run fill with --profile to generate statistically similar data.
+
+Only frequent values of non-key, non-PII, low-cardinality columns are stored
+literally (e.g. status codes); names, contacts, addresses, identifiers and free
+text are not.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := sourceConfig()
+		if err != nil {
+			return err
+		}
+		if err := loadSettings(); err != nil {
+			return err
+		}
+
+		fmt.Printf("🦅 Profiling %s (%s)\n", config.Name, config.Driver)
+
+		db, schemaName, err := openDB(config)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		d := dialect.GetDialect(config.Driver)
+
+		log.Println("Analyzing schema...")
+		allTables, err := schema.Analyze(db, d, schemaName)
+		if err != nil {
+			return err
+		}
+		targetTables, err := selectTables(allTables, profileTables)
+		if err != nil {
+			return err
+		}
+
+		opts := engine.DefaultProfileOptions()
+		opts.SampleRows, opts.TopK, opts.MinCount, opts.MaxDistinct = profileSample, profileTopK, profileMinCount, profileMaxDistinct
+
+		start := time.Now()
+		done := 0
+		p, err := engine.BuildProfile(db, d, targetTables, opts, func(table string) {
+			done++
+			log.Printf("Profiled %d/%d: %s", done, len(targetTables), table)
+		})
+		if err != nil {
+			return err
+		}
+		p.Driver, p.Schema = config.Driver, schemaName
+
+		if err := engine.SaveProfile(p, profileOutput); err != nil {
+			return fmt.Errorf("failed to write profile: %w", err)
+		}
+
+		fmt.Println("\n📊 Profile Summary:")
+		for i, t := range targetTables {
+			tp := p.Tables[t.Name]
+			fmt.Printf("[%02d/%02d] %-20s : %d rows (sampled %d), %d columns, %d FK fan-outs\n",
+				i+1, len(targetTables), t.Name, tp.Rows, tp.Sampled, len(tp.Columns), len(tp.FanOut))
+		}
+		fmt.Println("--------------------------------------------------")
+		log.Printf("Profile written to %s (%s)", profileOutput, time.Since(start))
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(profileCmd)
+
+	defaults := engine.DefaultProfileOptions()
+	profileCmd.Flags().StringVarP(&profileOutput, "output", "o", "db-pump.profile.yaml", "Profile file to write")
+	profileCmd.Flags().StringSliceVarP(&profileTables, "tables", "t", []string{}, "Specific tables to profile (comma-separated)")
+	profileCmd.Flags().IntVar(&profileSample, "sample", defaults.SampleRows, "Rows sampled per table")
+	profileCmd.Flags().IntVar(&profileTopK, "top-k", defaults.TopK, "Frequent values kept per column (0 = store no values)")
+	profileCmd.Flags().IntVar(&profileMinCount, "min-count", defaults.MinCount, "Keep a frequent value only if at least this many rows share it")
+	profileCmd.Flags().IntVar(&profileMaxDistinct, "max-distinct", defaults.MaxDistinct, "Keep values only of columns with at most this many distinct values (0 = no limit)")
+}
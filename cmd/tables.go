@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"db-pump/internal/schema"
+)
+
+// selectTables keeps the requested tables (case-insensitive), preserving dependency order.
+// An empty request selects every table.
+func selectTables(all []*schema.Table, names []string) ([]*schema.Table, error) {
+	if len(names) == 0 {
+		return all, nil
+	}
+	req := make(map[string]bool)
+	for _, n := range names {
+		req[strings.ToLower(strings.TrimSpace(n))] = true
+	}
+	var selected []*schema.Table
+	for _, t := range all {
+		if req[strings.ToLower(t.Name)] {
+			selected = append(selected, t)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no matching tables found for inputs: %v", names)
+	}
+	return selected, nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/engine"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	trainCorpus []string
+	trainTable  string
+	trainColumn string
+	trainSample int
+	trainOrder  int
+	trainLang   string
+	trainOutput string
+)
+
+var trainCmd = &cobra.Command{
+	Use:   "train",
+	Short: "Train a Markov text model for free-text columns",
+	Long: `Builds an n-gram (Markov chain) text model from corpus files or from values
+sampled from a column of the connected database, and writes it as a compact
+model file. Reference it under settings.text so title, description and comment
+columns read like plausible domain text.
+
+  db-pump train --corpus reviews.txt -o reviews.model
+  db-pump train --table film --column description -o film.model`,
+	// A corpus needs no database; sampling a column does
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if trainTable != "" {
+			return RootCmd.PersistentPreRunE(cmd, args)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(trainCorpus) == 0 && trainTable == "" {
+			return fmt.Errorf("nothing to train on: use --corpus and/or --table with --column")
+		}
+		if trainTable != "" && trainColumn == "" {
+			return fmt.Errorf("--table requires --column")
+		}
+
+		lang := trainLang
+		if lang == "" {
+			lang = viper.GetString("settings.language")
+		}
+		if lang == "" {
+			lang = "ko"
+		}
+		loc, ok := engine.LookupLocale(lang)
+		if !ok {
+			return fmt.Errorf("unsupported language %q (supported: ko, en, ja, zh)", lang)
+		}
+
+		var samples []string
+		for _, path := range trainCorpus {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read corpus: %w", err)
+			}
+			samples = append(samples, string(data))
+		}
+
+		if trainTable != "" {
+			values, err := sampleColumn(trainTable, trainColumn, trainSample)
+			if err != nil {
+				return err
+			}
+			log.Printf("Sampled %d values from %s.%s", len(values), trainTable, trainColumn)
+			samples = append(samples, values...)
+		}
+
+		m := engine.TrainTextModel(samples, trainOrder, loc.WordSep == "")
+		if len(m.States) == 0 {
+			return fmt.Errorf("no text found to train on")
+		}
+		if err := engine.SaveTextModel(m, trainOutput); err != nil {
+			return fmt.Errorf("failed to write model: %w", err)
+		}
+		log.Printf("Text model written to %s (order %d, %d states)", trainOutput, m.Order, len(m.States))
+		return nil
+	},
+}
+
+// sampleColumn reads up to limit non-NULL values of a column from the active database.
+func sampleColumn(table, column string, limit int) ([]string, error) {
+	var config DBConfig
+	activeConfig, err := GetActiveDBConfig()
+	if err == nil {
+		config = *activeConfig
+	} else {
+		config = DBConfig{Name: "CLI Wrapper", Driver: DriverName, DSN: dsn, Active: true}
+	}
+
+	db, err := sql.Open(config.Driver, config.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open db: %w", err)
+	}
+	defer db.Close()
+
+	d := dialect.GetDialect(config.Driver)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s IS NOT NULL", d.QuoteIdent(column), d.QuoteIdent(table), d.QuoteIdent(column))
+	rows, err := db.Query(d.GetLimitRowQuery(query, limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample %s.%s: %w", table, column, err)
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v sql.NullString
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		if v.Valid {
+			values = append(values, v.String)
+		}
+	}
+	return values, rows.Err()
+}
+
+func init() {
+	RootCmd.AddCommand(trainCmd)
+
+	trainCmd.Flags().StringSliceVar(&trainCorpus, "corpus", []string{}, "Corpus text files (comma-separated)")
+	trainCmd.Flags().StringVar(&trainTable, "table", "", "Table to sample text from")
+	trainCmd.Flags().StringVar(&trainColumn, "column", "", "Column to sample text from")
+	trainCmd.Flags().IntVar(&trainSample, "sample", 10000, "Rows sampled from --table")
+	trainCmd.Flags().IntVar(&trainOrder, "order", 2, "Markov order (tokens of context)")
+	trainCmd.Flags().StringVar(&trainLang, "lang", "", "Language of the text (default settings.language); ja/zh are modelled per character")
+	trainCmd.Flags().StringVarP(&trainOutput, "output", "o", "text.model", "Model file to write")
+}
@@ -0,0 +1,118 @@
+package dialect_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"db-pump/internal/dialect"
+)
+
+// recorded는 "recordexec" 드라이버가 받은 문장들이고, countResult는 모든 조회가 돌려주는 값이다.
+var (
+	recorded    []string
+	countResult int64
+)
+
+func init() { sql.Register("recordexec", recordDriver{}) }
+
+type recordDriver struct{}
+type recordConn struct{}
+type recordStmt struct{ query string }
+type recordRows struct{ done bool }
+
+func (recordDriver) Open(string) (driver.Conn, error) { return recordConn{}, nil }
+func (recordConn) Prepare(q string) (driver.Stmt, error) {
+	return recordStmt{q}, nil
+}
+func (recordConn) Close() error              { return nil }
+func (recordConn) Begin() (driver.Tx, error) { return recordConn{}, nil }
+func (recordConn) Commit() error             { return nil }
+func (recordConn) Rollback() error           { return nil }
+func (s recordStmt) Close() error            { return nil }
+func (s recordStmt) NumInput() int           { return -1 }
+func (s recordStmt) Exec([]driver.Value) (driver.Result, error) {
+	recorded = append(recorded, s.query)
+	return driver.RowsAffected(1), nil
+}
+func (s recordStmt) Query([]driver.Value) (driver.Rows, error) {
+	recorded = append(recorded, s.query)
+	return &recordRows{}, nil
+}
+func (r *recordRows) Columns() []string { return []string{"n"} }
+func (r *recordRows) Close() error      { return nil }
+func (r *recordRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = countResult
+	return nil
+}
+
+// runTableHooks는 BeforeTable/AfterTable을 한 트랜잭션에서 실행하고 보낸 문장을 돌려준다.
+func runTableHooks(t *testing.T, d dialect.Dialect, hasIdentity bool) ([]string, error) {
+	t.Helper()
+	db, _ := sql.Open("recordexec", "")
+	defer db.Close()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	recorded = nil
+	if err := d.BeforeTable(tx, "order", hasIdentity); err != nil {
+		return recorded, err
+	}
+	if err := d.AfterTable(tx, "order", hasIdentity); err != nil {
+		return recorded, err
+	}
+	return recorded, nil
+}
+
+func TestMSSQLTableHooks_IdentityInsert(t *testing.T) {
+	got, err := runTableHooks(t, dialect.GetDialect("sqlserver"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"ALTER TABLE [order] NOCHECK CONSTRAINT all",
+		"SET IDENTITY_INSERT [order] ON",
+		"SET IDENTITY_INSERT [order] OFF",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// 식별자 값을 직접 넣지 않으면 IDENTITY_INSERT를 켜지 않음 (켜 두면 값을 빼먹은 INSERT가 실패)
+	got, _ = runTableHooks(t, dialect.GetDialect("sqlserver"), false)
+	if len(got) != 1 || strings.Contains(got[0], "IDENTITY_INSERT") {
+		t.Errorf("hasIdentity=false: got %q", got)
+	}
+}
+
+func TestOracleTableHooks_RejectsAlwaysIdentity(t *testing.T) {
+	countResult = 1
+	defer func() { countResult = 0 }()
+	if _, err := runTableHooks(t, dialect.GetDialect("oracle"), true); !errors.Is(err, dialect.ErrExplicitIdentity) {
+		t.Errorf("GENERATED ALWAYS: got %v, want ErrExplicitIdentity", err)
+	}
+
+	countResult = 0
+	if _, err := runTableHooks(t, dialect.GetDialect("oracle"), true); err != nil {
+		t.Errorf("GENERATED BY DEFAULT: %v", err)
+	}
+}
+
+func TestMSSQLIdentityQueries(t *testing.T) {
+	ms := dialect.GetDialect("sqlserver").(*dialect.MSSQLDialect)
+	if got, want := ms.ReseedIdentityQuery("it's"), "DBCC CHECKIDENT ('[it''s]', RESEED, 0)"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if got, want := ms.SyncIdentityQuery("it's"), "DBCC CHECKIDENT ('[it''s]', RESEED)"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -1,25 +1,38 @@
 package dialect
 
-import "database/sql"
+import (
+	"database/sql"
+	"errors"
+)
+
+// ErrExplicitIdentity is wrapped by BeforeTable when the table's identity column can't
+// take explicit values; every INSERT would fail, so callers stop instead of warning.
+var ErrExplicitIdentity = errors.New("identity column cannot be written with explicit ids")
 
 // Dialect abstracts database-specific operations.
 type Dialect interface {
 	// Metadata Queries (Schema Introspection)
 	GetTablesQuery(schema string) string
-	GetColumnsQuery(schema string) string
+	GetColumnsQuery(schema string) string // (table, column, data_type, column_type, length, nullable, key, extra, unique, comment, precision, scale, unsigned, generated, default)
 	GetPrimaryKeysQuery(schema string) string
 	GetForeignKeysQuery(schema string) string
+	GetCheckConstraintsQuery(schema string) string // Returns (table, constraint, expression)
+	GetUniqueKeysQuery(schema string) string       // Returns (table, index, column) ordered by key position, PK excluded
+	GetPartitionsQuery(schema string) string       // Returns (table, partition, method, key, bound) in partition order; "" if not needed
 
 	// Execution Hooks (Global Level)
 	BeforePump(tx *sql.Tx) error
 	AfterPump(tx *sql.Tx) error
 
 	// Execution Hooks (Table Level) - For IDENTITY_INSERT etc.
+	// hasIdentity is true when the INSERTs write explicit values into the table's identity column.
 	BeforeTable(tx *sql.Tx, tableName string, hasIdentity bool) error
 	AfterTable(tx *sql.Tx, tableName string, hasIdentity bool) error
 
 	// Query Generation
-	InsertQuery(table string, cols []string) string
+	QuoteIdent(name string) string                                  // Quotes a table/column name (reserved words, mixed case, spaces)
+	InsertQuery(table string, cols []string, types []string) string // types may be nil; used to wrap special placeholders
+	ValueExpr(dataType string, placeholder string) string           // Wraps a placeholder for types that need a constructor (spatial)
 	TruncateQuery(table string) string
 	Placeholder(index int) string // Returns ?, $1, @p1, etc.
 
@@ -35,7 +35,16 @@ func (d *MSSQLDialect) GetColumnsQuery(schema string) string {
 				ELSE c.COLUMN_DEFAULT 
 			END AS COLUMN_DEFAULT,
 			CASE WHEN uq.COLUMN_NAME IS NOT NULL OR ui.COLUMN_NAME IS NOT NULL THEN 'UNIQUE' ELSE '' END AS IS_UNIQUE,
-			CAST(ep.value AS NVARCHAR(MAX)) AS COMMENT
+			CAST(ep.value AS NVARCHAR(MAX)) AS COMMENT,
+			c.NUMERIC_PRECISION,
+			c.NUMERIC_SCALE,
+			CASE WHEN c.DATA_TYPE = 'tinyint' THEN 'YES' ELSE 'NO' END AS IS_UNSIGNED, -- SQL Server tinyint is 0-255
+			CASE
+				WHEN COLUMNPROPERTY(OBJECT_ID(c.TABLE_SCHEMA + '.' + c.TABLE_NAME), c.COLUMN_NAME, 'IsComputed') = 1 THEN 'YES'
+				WHEN c.DATA_TYPE = 'timestamp' THEN 'YES' -- rowversion
+				ELSE 'NO'
+			END AS IS_GENERATED,
+			c.COLUMN_DEFAULT AS DEFAULT_VALUE
 		FROM INFORMATION_SCHEMA.COLUMNS c
 		LEFT JOIN (
 			SELECT kcu.TABLE_NAME, kcu.COLUMN_NAME
@@ -84,6 +93,29 @@ func (d *MSSQLDialect) GetForeignKeysQuery(schema string) string {
 	return `SELECT KCU1.TABLE_NAME, KCU1.CONSTRAINT_NAME, KCU1.COLUMN_NAME, KCU2.TABLE_NAME AS REF_TABLE, KCU2.COLUMN_NAME AS REF_COLUMN FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS RC JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE KCU1 ON RC.CONSTRAINT_NAME = KCU1.CONSTRAINT_NAME JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE KCU2 ON RC.UNIQUE_CONSTRAINT_NAME = KCU2.CONSTRAINT_NAME WHERE KCU1.TABLE_SCHEMA = @p1`
 }
 
+func (d *MSSQLDialect) GetCheckConstraintsQuery(schema string) string {
+	// SQL Server stores IN (...) as an OR chain, e.g. "([rating]='G' OR [rating]='PG')".
+	return `SELECT t.name, cc.name, cc.definition FROM sys.check_constraints cc JOIN sys.tables t ON cc.parent_object_id = t.object_id JOIN sys.schemas s ON t.schema_id = s.schema_id WHERE s.name = @p1`
+}
+
+func (d *MSSQLDialect) GetUniqueKeysQuery(schema string) string {
+	// UNIQUE constraints are backed by unique indexes, so sys.indexes covers both. Filtered indexes are skipped.
+	return `SELECT t.name, idx.name, col.name
+FROM sys.indexes idx
+JOIN sys.index_columns ic ON idx.object_id = ic.object_id AND idx.index_id = ic.index_id
+JOIN sys.columns col ON ic.object_id = col.object_id AND ic.column_id = col.column_id
+JOIN sys.tables t ON idx.object_id = t.object_id
+JOIN sys.schemas s ON t.schema_id = s.schema_id
+WHERE idx.is_unique = 1 AND idx.is_primary_key = 0 AND idx.has_filter = 0 AND ic.is_included_column = 0 AND s.name = @p1
+ORDER BY t.name, idx.name, ic.key_ordinal`
+}
+
+func (d *MSSQLDialect) GetPartitionsQuery(schema string) string {
+	// Partition functions cover the whole value domain, so every row lands somewhere;
+	// there is nothing to spread.
+	return ""
+}
+
 func (d *MSSQLDialect) BeforePump(tx *sql.Tx) error {
 	// Disable all constraints on all tables to allow bulk operations and avoid FK loops
 	// Using sp_msforeachtable is efficient but undocumented. Let's use standard loop.
@@ -104,7 +136,7 @@ func (d *MSSQLDialect) BeforePump(tx *sql.Tx) error {
 	rows.Close()
 
 	for _, t := range tables {
-		if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s NOCHECK CONSTRAINT all", t)); err != nil {
+		if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s NOCHECK CONSTRAINT all", d.QuoteIdent(t))); err != nil {
 			return fmt.Errorf("failed to disable constraints on %s: %w", t, err)
 		}
 	}
@@ -137,7 +169,7 @@ func (d *MSSQLDialect) AfterPump(tx *sql.Tx) error {
 		// Let's use simple CHECK CONSTRAINT all which enables it for future.
 		// Or "WITH CHECK CHECK CONSTRAINT all" to validate.
 		// Given we are generating random data that *should* be valid, let's try to validate.
-		if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s WITH CHECK CHECK CONSTRAINT all", t)); err != nil {
+		if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s WITH CHECK CHECK CONSTRAINT all", d.QuoteIdent(t))); err != nil {
 			// If validation fails, we might warn but still succeed?
 			// Or return error.
 			// Let's log it as error.
@@ -149,23 +181,66 @@ func (d *MSSQLDialect) AfterPump(tx *sql.Tx) error {
 
 func (d *MSSQLDialect) BeforeTable(tx *sql.Tx, tableName string, hasIdentity bool) error {
 	// Disable all constraints on this table to allow circular dependencies
-	_, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s NOCHECK CONSTRAINT all", tableName))
-	return err
+	if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s NOCHECK CONSTRAINT all", d.QuoteIdent(tableName))); err != nil {
+		return err
+	}
+	// Explicit values for an IDENTITY column are rejected unless IDENTITY_INSERT is on (per session, one table at a time)
+	if hasIdentity {
+		if _, err := tx.Exec(fmt.Sprintf("SET IDENTITY_INSERT %s ON", d.QuoteIdent(tableName))); err != nil {
+			return fmt.Errorf("%w: %v", ErrExplicitIdentity, err)
+		}
+	}
+	return nil
 }
 
 func (d *MSSQLDialect) AfterTable(tx *sql.Tx, tableName string, hasIdentity bool) error {
 	// Do not re-enable constraints here. We enable them globally in AfterPump.
 	// This supports circular dependencies (e.g. store <-> staff).
+	if hasIdentity {
+		_, err := tx.Exec(fmt.Sprintf("SET IDENTITY_INSERT %s OFF", d.QuoteIdent(tableName)))
+		return err
+	}
 	return nil
 }
 
-func (d *MSSQLDialect) InsertQuery(table string, cols []string) string {
-	vals := GeneratePlaceholders(len(cols), d.Placeholder)
-	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(cols, ", "), vals)
+func (d *MSSQLDialect) QuoteIdent(name string) string {
+	return QuoteWith(name, "[", "]")
+}
+
+func (d *MSSQLDialect) ValueExpr(dataType string, placeholder string) string {
+	// STGeomFromText instead of geography::Point(lat, lng, srid) so polygons work with the same single WKT parameter.
+	switch strings.ToLower(dataType) {
+	case "geography":
+		return fmt.Sprintf("geography::STGeomFromText(%s, 4326)", placeholder)
+	case "geometry":
+		return fmt.Sprintf("geometry::STGeomFromText(%s, 0)", placeholder)
+	}
+	return placeholder
+}
+
+func (d *MSSQLDialect) InsertQuery(table string, cols []string, types []string) string {
+	vals := GenerateValueExprs(len(cols), types, d.Placeholder, d.ValueExpr)
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", d.QuoteIdent(table), strings.Join(QuoteIdents(cols, d.QuoteIdent), ", "), vals)
 }
 
 func (d *MSSQLDialect) TruncateQuery(table string) string {
-	return fmt.Sprintf("TRUNCATE TABLE %s", table)
+	return fmt.Sprintf("TRUNCATE TABLE %s", d.QuoteIdent(table))
+}
+
+// ReseedIdentityQuery resets the IDENTITY seed of a table after a DELETE.
+func (d *MSSQLDialect) ReseedIdentityQuery(table string) string {
+	return d.checkIdentQuery(table, "RESEED, 0")
+}
+
+// SyncIdentityQuery moves the IDENTITY seed of a table up to its largest value after
+// rows were inserted with explicit ids.
+func (d *MSSQLDialect) SyncIdentityQuery(table string) string {
+	return d.checkIdentQuery(table, "RESEED")
+}
+
+// DBCC CHECKIDENT takes the table name as a string literal, so the quoted identifier is escaped again.
+func (d *MSSQLDialect) checkIdentQuery(table, args string) string {
+	return fmt.Sprintf("DBCC CHECKIDENT ('%s', %s)", strings.ReplaceAll(d.QuoteIdent(table), "'", "''"), args)
 }
 
 func (d *MSSQLDialect) Placeholder(index int) string {
@@ -13,7 +13,9 @@ func (d *MysqlDialect) GetTablesQuery(schema string) string {
 }
 
 func (d *MysqlDialect) GetColumnsQuery(schema string) string {
-	return `SELECT TABLE_NAME, COLUMN_NAME, DATA_TYPE, COLUMN_TYPE, CHARACTER_MAXIMUM_LENGTH, IS_NULLABLE, COLUMN_KEY, EXTRA, IF(COLUMN_KEY='UNI', 'UNIQUE', NULL) AS IS_UNIQUE, COLUMN_COMMENT FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? ORDER BY TABLE_NAME, ORDINAL_POSITION`
+	// EXTRA is "VIRTUAL GENERATED" / "STORED GENERATED" for GENERATED ALWAYS AS columns.
+	// ("DEFAULT_GENERATED" only marks DEFAULT CURRENT_TIMESTAMP and is insertable.)
+	return `SELECT TABLE_NAME, COLUMN_NAME, DATA_TYPE, COLUMN_TYPE, CHARACTER_MAXIMUM_LENGTH, IS_NULLABLE, COLUMN_KEY, EXTRA, IF(COLUMN_KEY='UNI', 'UNIQUE', NULL) AS IS_UNIQUE, COLUMN_COMMENT, NUMERIC_PRECISION, NUMERIC_SCALE, IF(COLUMN_TYPE LIKE '%unsigned%', 'YES', 'NO') AS IS_UNSIGNED, IF(EXTRA LIKE '%VIRTUAL GENERATED%' OR EXTRA LIKE '%STORED GENERATED%', 'YES', 'NO') AS IS_GENERATED, COLUMN_DEFAULT FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? ORDER BY TABLE_NAME, ORDINAL_POSITION`
 }
 
 func (d *MysqlDialect) GetPrimaryKeysQuery(schema string) string {
@@ -26,6 +28,22 @@ func (d *MysqlDialect) GetForeignKeysQuery(schema string) string {
 	return `SELECT TABLE_NAME, CONSTRAINT_NAME, COLUMN_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME FROM information_schema.KEY_COLUMN_USAGE WHERE TABLE_SCHEMA = ? AND REFERENCED_TABLE_NAME IS NOT NULL`
 }
 
+func (d *MysqlDialect) GetCheckConstraintsQuery(schema string) string {
+	// CHECK_CONSTRAINTS exists from MySQL 8.0.16. Older servers fail here and the analyzer skips CHECK parsing.
+	return `SELECT tc.TABLE_NAME, cc.CONSTRAINT_NAME, cc.CHECK_CLAUSE FROM information_schema.TABLE_CONSTRAINTS tc JOIN information_schema.CHECK_CONSTRAINTS cc ON tc.CONSTRAINT_SCHEMA = cc.CONSTRAINT_SCHEMA AND tc.CONSTRAINT_NAME = cc.CONSTRAINT_NAME WHERE tc.TABLE_SCHEMA = ? AND tc.CONSTRAINT_TYPE = 'CHECK'`
+}
+
+func (d *MysqlDialect) GetUniqueKeysQuery(schema string) string {
+	// COLUMN_KEY='UNI' only covers single-column keys; STATISTICS lists every member of composite ones.
+	return `SELECT TABLE_NAME, INDEX_NAME, COLUMN_NAME FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = ? AND NON_UNIQUE = 0 AND INDEX_NAME <> 'PRIMARY' AND COLUMN_NAME IS NOT NULL ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX`
+}
+
+func (d *MysqlDialect) GetPartitionsQuery(schema string) string {
+	// PARTITION_DESCRIPTION is the VALUES LESS THAN bound (RANGE) or the value list (LIST);
+	// PARTITION_EXPRESSION may wrap the column, e.g. to_days(`payment_date`).
+	return `SELECT TABLE_NAME, PARTITION_NAME, PARTITION_METHOD, PARTITION_EXPRESSION, PARTITION_DESCRIPTION FROM information_schema.PARTITIONS WHERE TABLE_SCHEMA = ? AND PARTITION_NAME IS NOT NULL ORDER BY TABLE_NAME, PARTITION_ORDINAL_POSITION`
+}
+
 func (d *MysqlDialect) BeforePump(tx *sql.Tx) error {
 	_, err := tx.Exec("SET FOREIGN_KEY_CHECKS = 0")
 	return err
@@ -45,13 +63,25 @@ func (d *MysqlDialect) AfterTable(tx *sql.Tx, tableName string, hasIdentity bool
 	return nil
 }
 
-func (d *MysqlDialect) InsertQuery(table string, cols []string) string {
-	vals := GeneratePlaceholders(len(cols), d.Placeholder)
-	return fmt.Sprintf("INSERT IGNORE INTO %s (%s) VALUES (%s)", table, strings.Join(cols, ", "), vals)
+func (d *MysqlDialect) QuoteIdent(name string) string {
+	return QuoteWith(name, "`", "`")
+}
+
+func (d *MysqlDialect) ValueExpr(dataType string, placeholder string) string {
+	// Columns may declare SRID 0 (e.g. Sakila address.location), so no SRID is forced here.
+	if IsSpatialType(dataType) {
+		return fmt.Sprintf("ST_GeomFromText(%s)", placeholder)
+	}
+	return placeholder
+}
+
+func (d *MysqlDialect) InsertQuery(table string, cols []string, types []string) string {
+	vals := GenerateValueExprs(len(cols), types, d.Placeholder, d.ValueExpr)
+	return fmt.Sprintf("INSERT IGNORE INTO %s (%s) VALUES (%s)", d.QuoteIdent(table), strings.Join(QuoteIdents(cols, d.QuoteIdent), ", "), vals)
 }
 
 func (d *MysqlDialect) TruncateQuery(table string) string {
-	return fmt.Sprintf("TRUNCATE TABLE %s", table)
+	return fmt.Sprintf("TRUNCATE TABLE %s", d.QuoteIdent(table))
 }
 
 func (d *MysqlDialect) Placeholder(index int) string {
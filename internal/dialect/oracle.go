@@ -19,6 +19,7 @@ func (d *OracleDialect) GetColumnsQuery(schema string) string {
 	// Retrieves column information for the current user's tables.
 	// We join with USER_CONS_COLUMNS to identify Primary Keys (P) and Unique (U) constraints.
 	// We also fetch comments from USER_COL_COMMENTS.
+	// Virtual columns are only flagged in USER_TAB_COLS.
 	return `
 SELECT
     t.TABLE_NAME,
@@ -34,7 +35,15 @@ SELECT
     CASE WHEN p.CONSTRAINT_NAME IS NOT NULL THEN 'PRI' ELSE '' END,
     CASE WHEN t.IDENTITY_COLUMN = 'YES' THEN 'auto_increment' ELSE '' END,
     CASE WHEN u.CONSTRAINT_NAME IS NOT NULL THEN 'UNIQUE' ELSE '' END,
-    c.COMMENTS
+    c.COMMENTS,
+    t.DATA_PRECISION,
+    t.DATA_SCALE,
+    'NO',
+    CASE WHEN EXISTS (
+        SELECT 1 FROM USER_TAB_COLS v
+        WHERE v.TABLE_NAME = t.TABLE_NAME AND v.COLUMN_NAME = t.COLUMN_NAME AND v.VIRTUAL_COLUMN = 'YES'
+    ) THEN 'YES' ELSE 'NO' END,
+    t.DATA_DEFAULT
 FROM USER_TAB_COLUMNS t
 LEFT JOIN (
     SELECT cc.TABLE_NAME, cc.COLUMN_NAME, cc.CONSTRAINT_NAME
@@ -87,6 +96,44 @@ WHERE c.CONSTRAINT_TYPE = 'R'
 AND :1 IS NOT NULL`
 }
 
+func (d *OracleDialect) GetCheckConstraintsQuery(schema string) string {
+	// SEARCH_CONDITION is a LONG; SEARCH_CONDITION_VC (12c+) is the VARCHAR2 copy.
+	// NOT NULL constraints are also type 'C' ("COL" IS NOT NULL) and are ignored by the parser.
+	return `
+SELECT TABLE_NAME, CONSTRAINT_NAME, SEARCH_CONDITION_VC
+FROM USER_CONSTRAINTS
+WHERE CONSTRAINT_TYPE = 'C' AND :1 IS NOT NULL`
+}
+
+func (d *OracleDialect) GetUniqueKeysQuery(schema string) string {
+	// Unique indexes (including those backing UNIQUE constraints), minus the PK index.
+	// Function-based indexes are skipped because their columns are hidden SYS_NC names.
+	return `
+SELECT i.TABLE_NAME, i.INDEX_NAME, ic.COLUMN_NAME
+FROM USER_INDEXES i
+JOIN USER_IND_COLUMNS ic ON i.INDEX_NAME = ic.INDEX_NAME
+WHERE i.UNIQUENESS = 'UNIQUE'
+AND i.INDEX_TYPE = 'NORMAL'
+AND NOT EXISTS (
+    SELECT 1 FROM USER_CONSTRAINTS c
+    WHERE c.CONSTRAINT_TYPE = 'P' AND c.INDEX_NAME = i.INDEX_NAME
+)
+AND :1 IS NOT NULL
+ORDER BY i.TABLE_NAME, i.INDEX_NAME, ic.COLUMN_POSITION`
+}
+
+func (d *OracleDialect) GetPartitionsQuery(schema string) string {
+	// HIGH_VALUE is the VALUES LESS THAN bound (RANGE) or the value list (LIST),
+	// e.g. TO_DATE(' 2022-01-01 00:00:00', 'SYYYY-MM-DD HH24:MI:SS', 'NLS_CALENDAR=GREGORIAN').
+	return `
+SELECT p.TABLE_NAME, p.PARTITION_NAME, t.PARTITIONING_TYPE, k.COLUMN_NAME, p.HIGH_VALUE
+FROM USER_TAB_PARTITIONS p
+JOIN USER_PART_TABLES t ON t.TABLE_NAME = p.TABLE_NAME
+LEFT JOIN USER_PART_KEY_COLUMNS k ON k.NAME = p.TABLE_NAME AND k.OBJECT_TYPE = 'TABLE' AND k.COLUMN_POSITION = 1
+WHERE :1 IS NOT NULL
+ORDER BY p.TABLE_NAME, p.PARTITION_POSITION`
+}
+
 func (d *OracleDialect) BeforePump(tx *sql.Tx) error {
 	// 1. Set NLS Formats to match Go's time format (standardizing on ISO-8601-like)
 	// Go's GenerateValue returns "2006-01-02 15:04:05" for dates.
@@ -120,9 +167,8 @@ func (d *OracleDialect) BeforePump(tx *sql.Tx) error {
 	rows.Close()
 
 	for _, c := range constraints {
-		// Oracle names are case sensitive if quoted, but typically stored upper case.
-		// We use standard identifiers.
-		query := fmt.Sprintf("ALTER TABLE %s DISABLE CONSTRAINT %s", c.Table, c.Name)
+		// Oracle names are case sensitive if quoted; the dictionary returns the stored case.
+		query := fmt.Sprintf("ALTER TABLE %s DISABLE CONSTRAINT %s", d.QuoteIdent(c.Table), d.QuoteIdent(c.Name))
 		if _, err := tx.Exec(query); err != nil {
 			return fmt.Errorf("failed to disable constraint %s on %s: %w", c.Name, c.Table, err)
 		}
@@ -153,7 +199,7 @@ func (d *OracleDialect) AfterPump(tx *sql.Tx) error {
 	rows.Close()
 
 	for _, c := range constraints {
-		query := fmt.Sprintf("ALTER TABLE %s ENABLE CONSTRAINT %s", c.Table, c.Name)
+		query := fmt.Sprintf("ALTER TABLE %s ENABLE CONSTRAINT %s", d.QuoteIdent(c.Table), d.QuoteIdent(c.Name))
 		if _, err := tx.Exec(query); err != nil {
 			// Don't fail hard if re-enabling fails (e.g. data violation), but valid pump shouldn't violate.
 			// Just log it or return error? Let's return error but user might ignore.
@@ -164,6 +210,17 @@ func (d *OracleDialect) AfterPump(tx *sql.Tx) error {
 }
 
 func (d *OracleDialect) BeforeTable(tx *sql.Tx, tableName string, hasIdentity bool) error {
+	// GENERATED BY DEFAULT identity columns take explicit values; GENERATED ALWAYS ones reject every row (ORA-32795)
+	if hasIdentity {
+		var always int
+		err := tx.QueryRow(`SELECT COUNT(*) FROM USER_TAB_IDENTITY_COLS WHERE TABLE_NAME = :1 AND GENERATION_TYPE = 'ALWAYS'`, tableName).Scan(&always)
+		if err != nil {
+			return err
+		}
+		if always > 0 {
+			return fmt.Errorf("%w: %s has a GENERATED ALWAYS identity column (alter it to GENERATED BY DEFAULT)", ErrExplicitIdentity, tableName)
+		}
+	}
 	return nil
 }
 
@@ -171,19 +228,36 @@ func (d *OracleDialect) AfterTable(tx *sql.Tx, tableName string, hasIdentity boo
 	return nil
 }
 
-func (d *OracleDialect) InsertQuery(table string, cols []string) string {
-	vals := GeneratePlaceholders(len(cols), d.Placeholder)
+func (d *OracleDialect) QuoteIdent(name string) string {
+	// Quoted identifiers are case-sensitive; USER_TABLES / USER_TAB_COLUMNS return the stored (usually upper) case.
+	return QuoteWith(name, `"`, `"`)
+}
+
+func (d *OracleDialect) ValueExpr(dataType string, placeholder string) string {
+	// SDO_GEOMETRY has a (WKT, SRID) constructor.
+	if IsSpatialType(dataType) {
+		return fmt.Sprintf("SDO_GEOMETRY(%s, 4326)", placeholder)
+	}
+	// Values carry an offset ("2024-03-01 09:30:00+09:00"), which the NLS default format doesn't parse
+	if strings.Contains(dataType, "time zone") {
+		return fmt.Sprintf("TO_TIMESTAMP_TZ(%s, 'YYYY-MM-DD HH24:MI:SSTZH:TZM')", placeholder)
+	}
+	return placeholder
+}
+
+func (d *OracleDialect) InsertQuery(table string, cols []string, types []string) string {
+	vals := GenerateValueExprs(len(cols), types, d.Placeholder, d.ValueExpr)
 	// Debugging: Print problematic SQL
 	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
-		table,
-		strings.Join(cols, ", "),
+		d.QuoteIdent(table),
+		strings.Join(QuoteIdents(cols, d.QuoteIdent), ", "),
 		vals)
 	// fmt.Printf("[DEBUG SQL] %s\n", sql)
 	return sql
 }
 
 func (d *OracleDialect) TruncateQuery(table string) string {
-	return fmt.Sprintf("TRUNCATE TABLE %s", table)
+	return fmt.Sprintf("TRUNCATE TABLE %s", d.QuoteIdent(table))
 }
 
 func (d *OracleDialect) Placeholder(index int) string {
@@ -199,6 +273,13 @@ func (d *OracleDialect) NormalizeType(sqlType string) string {
 	if strings.Contains(s, "int") || strings.Contains(s, "number") || strings.Contains(s, "float") {
 		return "integer"
 	}
+	// Keep the zone marker: such columns get values with an offset (TIMESTAMP(6) WITH TIME ZONE)
+	if strings.Contains(s, "with local time zone") {
+		return "timestamp with local time zone"
+	}
+	if strings.Contains(s, "with time zone") {
+		return "timestamp with time zone"
+	}
 	if strings.Contains(s, "date") || strings.Contains(s, "time") || strings.Contains(s, "year") {
 		return "datetime"
 	}
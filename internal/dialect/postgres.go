@@ -27,24 +27,41 @@ func (d *PostgresDialect) GetColumnsQuery(schema string) string {
 	// We select UDT_NAME as DATA_TYPE here for better processing later if needed, or stick to standard.
 	// We also select COLUMN_DEFAULT as the last column (EXTRA in MySQL).
 	// Subqueries used to fetch PRIMARY KEY and UNIQUE constraints correctly.
+	// Enum columns report their labels in the udt_name slot as "enum('a','b')" (same shape as MySQL COLUMN_TYPE).
+	// Domain columns already report the base type in data_type/udt_name; domain CHECKs come from GetCheckConstraintsQuery.
+	// GENERATED ALWAYS AS (...) STORED columns have is_generated = 'ALWAYS'; identity columns are reported as 'identity'.
+	// Array columns report data_type 'ARRAY' and the element type as udt_name with a leading underscore (_text, _int4).
+	// PostGIS columns report their full declared type (geometry(Point,4326)) so inserts can use the column's SRID.
 	return `SELECT 
     c.table_name, 
     c.column_name, 
     c.data_type, 
-    c.udt_name, 
+    CASE WHEN t.typtype = 'e' THEN
+        'enum(' || (SELECT string_agg(quote_literal(e.enumlabel), ',' ORDER BY e.enumsortorder) FROM pg_enum e WHERE e.enumtypid = t.oid) || ')'
+    WHEN c.udt_name IN ('geometry', 'geography') THEN
+        (SELECT format_type(a.atttypid, a.atttypmod) FROM pg_attribute a
+         WHERE a.attrelid = (quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass AND a.attname = c.column_name)
+    ELSE c.udt_name END AS udt_name, 
     c.character_maximum_length, 
     c.is_nullable, 
     (SELECT 'PRI' FROM information_schema.table_constraints tc 
      JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name 
      WHERE tc.constraint_type = 'PRIMARY KEY' 
      AND kcu.table_schema = c.table_schema AND kcu.table_name = c.table_name AND kcu.column_name = c.column_name LIMIT 1) AS COLUMN_KEY,
-    c.column_default, 
+    CASE WHEN c.is_identity = 'YES' THEN 'identity' ELSE c.column_default END AS column_default, 
     (SELECT 'UNIQUE' FROM information_schema.table_constraints tc 
      JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name 
      WHERE tc.constraint_type = 'UNIQUE' 
      AND kcu.table_schema = c.table_schema AND kcu.table_name = c.table_name AND kcu.column_name = c.column_name LIMIT 1) AS IS_UNIQUE,
-    NULL AS COMMENT
+    NULL AS COMMENT,
+    c.numeric_precision,
+    c.numeric_scale,
+    'NO' AS IS_UNSIGNED,
+    CASE WHEN c.is_generated = 'ALWAYS' THEN 'YES' ELSE 'NO' END AS IS_GENERATED,
+    c.column_default AS DEFAULT_VALUE
 FROM information_schema.columns c
+LEFT JOIN (pg_type t JOIN pg_namespace tn ON tn.oid = t.typnamespace)
+    ON t.typname = c.udt_name AND tn.nspname = c.udt_schema
 WHERE c.table_schema = $1 
 ORDER BY c.table_name, c.ordinal_position`
 }
@@ -57,6 +74,55 @@ func (d *PostgresDialect) GetForeignKeysQuery(schema string) string {
 	return `SELECT kcu.table_name, kcu.constraint_name, kcu.column_name, ccu.table_name AS referenced_table_name, ccu.column_name AS referenced_column_name FROM information_schema.key_column_usage kcu JOIN information_schema.constraint_column_usage ccu ON kcu.constraint_name = ccu.constraint_name JOIN information_schema.table_constraints tc ON kcu.constraint_name = tc.constraint_name WHERE kcu.table_schema = $1 AND tc.constraint_type = 'FOREIGN KEY'`
 }
 
+func (d *PostgresDialect) GetCheckConstraintsQuery(schema string) string {
+	// pg_get_constraintdef gives the normalized "CHECK (...)" text, e.g. "CHECK ((rating = ANY (ARRAY[...])))".
+	// The second half attaches domain CHECKs (written against VALUE) to every column using the domain.
+	return `SELECT rel.relname, con.conname, pg_get_constraintdef(con.oid)
+FROM pg_constraint con
+JOIN pg_class rel ON rel.oid = con.conrelid
+JOIN pg_namespace nsp ON nsp.oid = rel.relnamespace
+WHERE nsp.nspname = $1 AND con.contype = 'c'
+UNION ALL
+SELECT c.table_name, con.conname, regexp_replace(pg_get_constraintdef(con.oid), '\mVALUE\M', quote_ident(c.column_name), 'g')
+FROM information_schema.columns c
+JOIN pg_namespace tn ON tn.nspname = c.domain_schema
+JOIN pg_type typ ON typ.typname = c.domain_name AND typ.typnamespace = tn.oid
+JOIN pg_constraint con ON con.contypid = typ.oid AND con.contype = 'c'
+WHERE c.table_schema = $1`
+}
+
+func (d *PostgresDialect) GetUniqueKeysQuery(schema string) string {
+	// Covers UNIQUE constraints and CREATE UNIQUE INDEX alike.
+	// Partial (WHERE ...) and expression indexes are skipped since they don't constrain plain column tuples.
+	return `SELECT t.relname, i.relname, a.attname
+FROM pg_index x
+JOIN pg_class t ON t.oid = x.indrelid
+JOIN pg_class i ON i.oid = x.indexrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+JOIN LATERAL unnest(x.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+WHERE n.nspname = $1 AND x.indisunique AND NOT x.indisprimary AND x.indpred IS NULL AND x.indexprs IS NULL
+ORDER BY t.relname, i.relname, k.ord`
+}
+
+func (d *PostgresDialect) GetPartitionsQuery(schema string) string {
+	// Declarative partitioning (PG 10+). Bounds come from pg_get_expr, e.g.
+	// "FOR VALUES FROM ('2022-01-01 00:00:00+00') TO ('2022-02-01 00:00:00+00')".
+	// Expression keys have attnum 0 and report no key column.
+	return `SELECT p.relname, c.relname,
+    CASE pt.partstrat WHEN 'r' THEN 'RANGE' WHEN 'l' THEN 'LIST' ELSE 'HASH' END,
+    a.attname,
+    pg_get_expr(c.relpartbound, c.oid)
+FROM pg_partitioned_table pt
+JOIN pg_class p ON p.oid = pt.partrelid
+JOIN pg_namespace n ON n.oid = p.relnamespace
+JOIN pg_inherits i ON i.inhparent = p.oid
+JOIN pg_class c ON c.oid = i.inhrelid
+LEFT JOIN pg_attribute a ON a.attrelid = p.oid AND a.attnum = pt.partattrs[0]
+WHERE n.nspname = $1
+ORDER BY p.relname, c.relname`
+}
+
 func (d *PostgresDialect) BeforePump(tx *sql.Tx) error {
 	// Use DEFERRED constraints for circular dependencies.
 	// This works for foreign keys defined as DEFERRABLE.
@@ -89,17 +155,33 @@ func (d *PostgresDialect) AfterTable(tx *sql.Tx, tableName string, hasIdentity b
 	return err
 }
 
-func (d *PostgresDialect) InsertQuery(table string, cols []string) string {
+func (d *PostgresDialect) QuoteIdent(name string) string {
+	// Quoted identifiers are case-sensitive, which matches the names returned by information_schema.
+	return QuoteWith(name, `"`, `"`)
+}
+
+func (d *PostgresDialect) ValueExpr(dataType string, placeholder string) string {
+	// PostGIS: the WKT gets the column's declared SRID (a mismatch is rejected); geography casts implicitly.
+	if IsSpatialType(dataType) {
+		if srid := SpatialSRID(dataType); srid != "" {
+			return fmt.Sprintf("ST_GeomFromText(%s, %s)", placeholder, srid)
+		}
+		return fmt.Sprintf("ST_GeomFromText(%s)", placeholder)
+	}
+	return placeholder
+}
+
+func (d *PostgresDialect) InsertQuery(table string, cols []string, types []string) string {
 	// Generate placeholders ($1, $2, ...)
-	vals := GeneratePlaceholders(len(cols), d.Placeholder)
+	vals := GenerateValueExprs(len(cols), types, d.Placeholder, d.ValueExpr)
 
 	// RETURNING clause logic is handled in Pumper currently via string concat hack.
 	// We just return base INSERT ... ON CONFLICT DO NOTHING.
-	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT DO NOTHING", table, strings.Join(cols, ", "), vals)
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT DO NOTHING", d.QuoteIdent(table), strings.Join(QuoteIdents(cols, d.QuoteIdent), ", "), vals)
 }
 
 func (d *PostgresDialect) TruncateQuery(table string) string {
-	return fmt.Sprintf("TRUNCATE TABLE %s CASCADE", table)
+	return fmt.Sprintf("TRUNCATE TABLE %s CASCADE", d.QuoteIdent(table))
 }
 
 func (d *PostgresDialect) Placeholder(index int) string {
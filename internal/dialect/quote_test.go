@@ -0,0 +1,77 @@
+package dialect_test
+
+import (
+	"db-pump/internal/dialect"
+	"strings"
+	"testing"
+)
+
+func TestQuoteIdent_AllDialects(t *testing.T) {
+	// 예약어, 대소문자 혼용, 공백, 인용부호가 포함된 이름
+	cases := []struct {
+		driver string
+		in     string
+		want   string
+	}{
+		{"mysql", "order", "`order`"},
+		{"mysql", "we`ird", "`we``ird`"},
+		{"postgres", "User", `"User"`},
+		{"postgres", `a"b`, `"a""b"`},
+		{"sqlserver", "order details", "[order details]"},
+		{"sqlserver", "a]b", "[a]]b]"},
+		{"oracle", "USER", `"USER"`},
+	}
+
+	for _, c := range cases {
+		got := dialect.GetDialect(c.driver).QuoteIdent(c.in)
+		if got != c.want {
+			t.Errorf("%s QuoteIdent(%q) = %s, want %s", c.driver, c.in, got, c.want)
+		}
+	}
+}
+
+func TestInsertQuery_QuotesTableAndColumns(t *testing.T) {
+	q := dialect.GetDialect("postgres").InsertQuery("order", []string{"user", "Amount"}, nil)
+	want := `INSERT INTO "order" ("user", "Amount") VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	if q != want {
+		t.Errorf("got %s, want %s", q, want)
+	}
+}
+
+func TestInsertQuery_WrapsSpatialColumns(t *testing.T) {
+	q := dialect.GetDialect("mysql").InsertQuery("address", []string{"address", "location"}, []string{"varchar", "geometry"})
+	want := "INSERT IGNORE INTO `address` (`address`, `location`) VALUES (?, ST_GeomFromText(?))"
+	if q != want {
+		t.Errorf("got %s, want %s", q, want)
+	}
+}
+
+func TestInsertQuery_PostgresUsesDeclaredSRID(t *testing.T) {
+	d := dialect.GetDialect("postgres")
+	cases := []struct {
+		dataType string
+		want     string
+	}{
+		{"geometry(point,4326)", "ST_GeomFromText($1, 4326)"},
+		{"geometry(polygon,5179)", "ST_GeomFromText($1, 5179)"},
+		{"geometry(point)", "ST_GeomFromText($1)"},
+		{"geometry", "ST_GeomFromText($1)"},
+	}
+	for _, c := range cases {
+		q := d.InsertQuery("place", []string{"location"}, []string{c.dataType})
+		want := `INSERT INTO "place" ("location") VALUES (` + c.want + `) ON CONFLICT DO NOTHING`
+		if q != want {
+			t.Errorf("%s: got %s, want %s", c.dataType, q, want)
+		}
+	}
+}
+
+func TestInsertQuery_OracleParsesTimezoneValues(t *testing.T) {
+	d := dialect.GetDialect("oracle")
+	types := []string{d.NormalizeType("TIMESTAMP(6) WITH TIME ZONE"), d.NormalizeType("TIMESTAMP(6)")}
+	q := d.InsertQuery("EVENTS", []string{"AT_TZ", "AT"}, types)
+	want := `VALUES (TO_TIMESTAMP_TZ(:1, 'YYYY-MM-DD HH24:MI:SSTZH:TZM'), :2)`
+	if !strings.Contains(q, want) {
+		t.Errorf("got %s, want it to contain %s", q, want)
+	}
+}
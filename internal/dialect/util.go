@@ -1,6 +1,7 @@
 package dialect
 
 import (
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +16,52 @@ func GeneratePlaceholders(count int, placeholderFunc func(int) string) string {
 	return strings.Join(placeholders, ", ")
 }
 
+// GenerateValueExprs is like GeneratePlaceholders, but passes each placeholder through
+// exprFunc with the column's data type so dialects can wrap it (e.g. ST_GeomFromText(?)).
+// types may be nil or shorter than count; missing entries are treated as plain columns.
+func GenerateValueExprs(count int, types []string, placeholderFunc func(int) string, exprFunc func(string, string) string) string {
+	exprs := make([]string, count)
+	for i := 0; i < count; i++ {
+		dataType := ""
+		if i < len(types) {
+			dataType = types[i]
+		}
+		exprs[i] = exprFunc(dataType, placeholderFunc(i))
+	}
+	return strings.Join(exprs, ", ")
+}
+
+// IsSpatialType reports whether a (normalized) data type holds geometry values.
+// A type modifier is ignored: geometry(point,4326) is spatial.
+func IsSpatialType(dataType string) bool {
+	base, _, _ := strings.Cut(dataType, "(")
+	switch strings.ToLower(strings.TrimSpace(base)) {
+	case "geometry", "geography", "point", "polygon", "linestring",
+		"multipoint", "multipolygon", "multilinestring", "geometrycollection", "sdo_geometry":
+		return true
+	}
+	return false
+}
+
+// SpatialSRID returns the SRID declared in a type modifier such as geometry(point,4326),
+// or "" when the type declares none (geometry, geometry(point)).
+func SpatialSRID(dataType string) string {
+	_, mod, ok := strings.Cut(dataType, "(")
+	if !ok {
+		return ""
+	}
+	mod = strings.TrimSuffix(strings.TrimSpace(mod), ")")
+	_, srid, ok := strings.Cut(mod, ",")
+	if !ok {
+		return ""
+	}
+	srid = strings.TrimSpace(srid)
+	if _, err := strconv.Atoi(srid); err != nil {
+		return ""
+	}
+	return srid
+}
+
 // DefaultNormalizeType is a default implementation for type normalization (lowercase).
 func DefaultNormalizeType(sqlType string) string {
 	return strings.ToLower(sqlType)
@@ -24,3 +71,17 @@ func DefaultNormalizeType(sqlType string) string {
 func DefaultGetSchemaName(input string) string {
 	return input
 }
+
+// QuoteWith wraps name in the given quote characters, doubling any embedded closing quote.
+func QuoteWith(name, open, close string) string {
+	return open + strings.ReplaceAll(name, close, close+close) + close
+}
+
+// QuoteIdents applies quoteFunc to every identifier in names.
+func QuoteIdents(names []string, quoteFunc func(string) string) []string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = quoteFunc(n)
+	}
+	return quoted
+}
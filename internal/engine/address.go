@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Region is one 시/군/구 of a hierarchical address dataset, with the 우편번호 range it owns.
+type Region struct {
+	Province string // 시/도 (서울특별시, 경기도)
+	District string // 시/군/구 (강남구, 수원시 영통구)
+	ZipFrom  int    // 우편번호 범위 (포함)
+	ZipTo    int
+	Roads    []string // 도로명
+}
+
+// rowAddress is the address picked for the current row; city, district, street,
+// address and postal code columns of the row all describe it.
+type rowAddress struct {
+	region   *Region
+	road     string
+	building int
+	zip      string
+}
+
+// loadRegions reads an embedded "sido,sigungu,zip_from,zip_to,road|road|..." file.
+func loadRegions(path string) []*Region {
+	data, err := embeddedData.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("%s: %v", path, err))
+	}
+
+	var regions []*Region
+	for _, rec := range records[1:] { // header
+		from, err1 := strconv.Atoi(rec[2])
+		to, err2 := strconv.Atoi(rec[3])
+		if err1 != nil || err2 != nil {
+			panic(fmt.Sprintf("%s: invalid zip range %v", path, rec))
+		}
+		regions = append(regions, &Region{Province: rec[0], District: rec[1], ZipFrom: from, ZipTo: to, Roads: strings.Split(rec[4], "|")})
+	}
+	return regions
+}
+
+// rowAddress returns the row's address when the locale has a region dataset.
+// Configured cities/districts/streets dictionaries take precedence, in which case
+// the flat lists are used and nil is returned.
+func (l *Locale) rowAddress() *rowAddress {
+	if len(l.Regions) == 0 {
+		return nil
+	}
+	for _, name := range []string{"cities", "districts", "streets"} {
+		if _, ok := loadedDictionaries[name]; ok {
+			return nil
+		}
+	}
+	if currentRow.address == nil {
+		r := l.Regions[seededRand.Intn(len(l.Regions))]
+		currentRow.address = &rowAddress{
+			region:   r,
+			road:     r.Roads[seededRand.Intn(len(r.Roads))],
+			building: seededRand.Intn(300) + 1,
+			zip:      fmt.Sprintf("%05d", r.ZipFrom+seededRand.Intn(r.ZipTo-r.ZipFrom+1)),
+		}
+	}
+	return currentRow.address
+}
+
+// city is what a standalone city column holds: the metropolitan city itself
+// (서울특별시), or the 시/군 inside a province (경기도 수원시 영통구 → 수원시).
+func (a *rowAddress) city() string {
+	if strings.HasSuffix(a.region.Province, "도") {
+		return strings.Fields(a.region.District)[0]
+	}
+	return a.region.Province
+}
+
+// district is what a standalone district column holds: the innermost 시/군/구 (영통구).
+func (a *rowAddress) district() string {
+	parts := strings.Fields(a.region.District)
+	return parts[len(parts)-1]
+}
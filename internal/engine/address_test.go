@@ -0,0 +1,32 @@
+package engine
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"db-pump/internal/schema"
+)
+
+func TestGenerateValue_KoreanAddressColumnsAgree(t *testing.T) {
+	city := &schema.Column{Name: "city", DataType: "varchar", Meaning: "city"}
+	district := &schema.Column{Name: "district", DataType: "varchar", Meaning: "district"}
+	address := &schema.Column{Name: "address", DataType: "varchar", Meaning: "address"}
+	zip := &schema.Column{Name: "postal_code", DataType: "varchar", Meaning: "postal code"}
+
+	for i := 0; i < 50; i++ {
+		beginRow() // 행마다 새 주소
+		c := GenerateValue(city, "address").(string)
+		d := GenerateValue(district, "address").(string)
+		a := GenerateValue(address, "address").(string)
+		z := GenerateValue(zip, "address").(string)
+
+		// 같은 행의 시/구/주소가 같은 지역을 가리켜야 한다 (예: 부산 + 강남구 조합 금지)
+		if !strings.Contains(a, c) || !strings.Contains(a, d) {
+			t.Fatalf("row %d: address %q does not match city %q / district %q", i, a, c, d)
+		}
+		if ok, _ := regexp.MatchString(`^\d{5}$`, z); !ok {
+			t.Fatalf("row %d: postal code %q is not a 5-digit 우편번호", i, z)
+		}
+	}
+}
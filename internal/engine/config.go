@@ -0,0 +1,48 @@
+package engine
+
+// Config holds the generation settings read from the "settings" section of db-pump.yaml.
+// Fields missing from the file keep the values from DefaultConfig.
+type Config struct {
+	Language     string             `mapstructure:"language"`      // locale pack: "ko" (default), "en", "ja", "zh"
+	IntegerRange string             `mapstructure:"integer_range"` // "realistic" (default) or "full"
+	Array        ArrayConfig        `mapstructure:"array"`
+	JSON         []JSONColumnConfig `mapstructure:"json"`
+	Spatial      SpatialConfig      `mapstructure:"spatial"`
+	Defaults     DefaultsConfig     `mapstructure:"defaults"`
+	Unique       UniqueConfig       `mapstructure:"unique"`
+	Temporal     TemporalConfig     `mapstructure:"temporal"`
+	DateRange    string             `mapstructure:"date_range"` // e.g. "-1y..now", "2020-01-01..2024-12-31"
+	DateColumns  []DateColumnConfig `mapstructure:"date_columns"`
+	Timezone     string             `mapstructure:"timezone"` // IANA name; empty = local zone
+	Seasonality  SeasonalityConfig  `mapstructure:"seasonality"`
+	Dictionaries map[string]string  `mapstructure:"dictionaries"` // dictionary name → file (.txt, .csv, .yaml)
+	Identifiers  IdentifierConfig   `mapstructure:"identifiers"`
+	Persona      PersonaConfig      `mapstructure:"persona"`
+	Profile      ProfileConfig      `mapstructure:"profile"`
+	Text         TextConfig         `mapstructure:"text"`
+	Password     PasswordConfig     `mapstructure:"password"`
+	Mask         MaskConfig         `mapstructure:"mask"`
+}
+
+// ArrayConfig controls element counts for array columns (PostgreSQL text[], int[], ...).
+type ArrayConfig struct {
+	MinLength int `mapstructure:"min_length"`
+	MaxLength int `mapstructure:"max_length"`
+}
+
+// Settings is the active generation config. cmd populates it before pumping.
+var Settings = DefaultConfig()
+
+// DefaultConfig returns the built-in generation settings.
+func DefaultConfig() Config {
+	return Config{
+		Language:     "ko",
+		IntegerRange: RangeRealistic,
+		Array:        ArrayConfig{MinLength: 1, MaxLength: 4},
+		Spatial:      SpatialConfig{MinLat: 33.1, MaxLat: 38.6, MinLng: 124.6, MaxLng: 131.9}, // South Korea
+		Unique:       UniqueConfig{Preload: true, BloomThreshold: 1000000},
+		Temporal:     TemporalConfig{Infer: true, MaxGapDays: 30},
+		DateRange:    DefaultDateRange,
+		Persona:      PersonaConfig{MinAge: 18, MaxAge: 80},
+	}
+}
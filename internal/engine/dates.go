@@ -0,0 +1,257 @@
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+
+	"db-pump/internal/schema"
+)
+
+// DefaultDateRange is used when settings.date_range is empty or invalid.
+const DefaultDateRange = "-1y..now"
+
+// DateColumnConfig overrides settings.date_range for one column.
+type DateColumnConfig struct {
+	Table  string `mapstructure:"table"` // optional; empty matches the column in any table
+	Column string `mapstructure:"column"`
+	Range  string `mapstructure:"range"`
+}
+
+// SeasonalityConfig skews generated dates. Each list holds relative weights;
+// an empty list means a uniform distribution.
+type SeasonalityConfig struct {
+	Months   []float64 `mapstructure:"months"`   // 12 weights, January first
+	Weekdays []float64 `mapstructure:"weekdays"` // 7 weights, Monday first
+	Hours    []float64 `mapstructure:"hours"`    // 24 weights, 00시 first
+}
+
+// ValidateSeasonality reports a seasonality list of the wrong length: a short list
+// would silently leave the missing months, weekdays or hours without any dates.
+func ValidateSeasonality(c SeasonalityConfig) error {
+	for _, l := range []struct {
+		name    string
+		weights []float64
+		want    int
+	}{{"months", c.Months, 12}, {"weekdays", c.Weekdays, 7}, {"hours", c.Hours, 24}} {
+		if len(l.weights) != 0 && len(l.weights) != l.want {
+			return fmt.Errorf("%s has %d weights, want %d (or none for a uniform distribution)", l.name, len(l.weights), l.want)
+		}
+	}
+	return nil
+}
+
+type dateSpan struct {
+	from, to time.Time
+}
+
+// dateSpans caches parsed ranges by range and timezone. Relative ranges are resolved once per run.
+var dateSpans = make(map[string]dateSpan)
+
+var reRelative = regexp.MustCompile(`^([+-])((?:\d+[ymwdh])+)$`)
+var reRelativePart = regexp.MustCompile(`(\d+)([ymwdh])`)
+
+// dateWindowFor is the range dates of the column are drawn from:
+// its date_columns override, otherwise settings.date_range, narrowed to the row's partition.
+func dateWindowFor(col *schema.Column, tableName string) (time.Time, time.Time) {
+	spec, ok := dateColumnRange(col, tableName)
+	if !ok {
+		spec = Settings.DateRange
+	}
+	if spec == "" {
+		spec = DefaultDateRange
+	}
+
+	loc := dateLocation()
+	key := spec + "@" + loc.String() // the same range reads differently in another timezone
+	s, ok := dateSpans[key]
+	if !ok {
+		from, to, err := ParseDateRange(spec, time.Now().In(loc))
+		if err != nil {
+			fmt.Printf("[Dates] Warning: %v, using %s\n", err, DefaultDateRange)
+			from, to, _ = ParseDateRange(DefaultDateRange, time.Now().In(loc))
+		}
+		s = dateSpan{from, to}
+		dateSpans[key] = s
+	}
+
+	// The partition key of a partitioned table stays inside the row's partition
+	if isPartitionKey(col) {
+		return partitionWindow(s.from, s.to)
+	}
+	return s.from, s.to
+}
+
+// dateColumnRange returns the settings.date_columns range configured for the column, if any.
+func dateColumnRange(col *schema.Column, tableName string) (string, bool) {
+	for _, c := range Settings.DateColumns {
+		if strings.EqualFold(c.Column, col.Name) && (c.Table == "" || strings.EqualFold(c.Table, tableName)) {
+			return c.Range, true
+		}
+	}
+	return "", false
+}
+
+// ParseDateRange parses "<from>..<to>" where each end is "now", "today", a relative
+// offset from now such as "-5y", "-6m", "-2w", "+30d", "-1y6m", or an absolute date
+// ("2020-01-01", "2020-01-01 09:00:00"). Absolute dates are read in settings.timezone.
+func ParseDateRange(spec string, now time.Time) (time.Time, time.Time, error) {
+	parts := strings.Split(spec, "..")
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date range %q (expected <from>..<to>)", spec)
+	}
+	from, err := parseDateBound(parts[0], now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := parseDateBound(parts[1], now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date range %q (end before start)", spec)
+	}
+	return from, to, nil
+}
+
+func parseDateBound(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	switch s {
+	case "now":
+		return now, nil
+	case "today":
+		y, m, d := now.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, now.Location()), nil
+	}
+
+	if m := reRelative.FindStringSubmatch(s); m != nil {
+		sign := 1
+		if m[1] == "-" {
+			sign = -1
+		}
+		t := now
+		for _, p := range reRelativePart.FindAllStringSubmatch(m[2], -1) {
+			n, _ := strconv.Atoi(p[1])
+			n *= sign
+			switch p[2] {
+			case "y":
+				t = t.AddDate(n, 0, 0)
+			case "m":
+				t = t.AddDate(0, n, 0)
+			case "w":
+				t = t.AddDate(0, 0, 7*n)
+			case "d":
+				t = t.AddDate(0, 0, n)
+			case "h":
+				t = t.Add(time.Duration(n) * time.Hour)
+			}
+		}
+		return t, nil
+	}
+
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05", "2006-01-02t15:04:05", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// loadedZone caches the last loaded settings.timezone (LoadLocation reads tzdata on every call).
+var loadedZone struct {
+	name string
+	loc  *time.Location
+}
+
+// dateLocation is settings.timezone (an IANA name such as "Asia/Seoul"), or the local zone.
+func dateLocation() *time.Location {
+	if Settings.Timezone == "" {
+		return time.Local
+	}
+	if loadedZone.loc == nil || loadedZone.name != Settings.Timezone {
+		loc, err := time.LoadLocation(Settings.Timezone)
+		if err != nil {
+			fmt.Printf("[Dates] Warning: unknown timezone %q, using local time\n", Settings.Timezone)
+			loc = time.Local
+		}
+		loadedZone.name, loadedZone.loc = Settings.Timezone, loc
+	}
+	return loadedZone.loc
+}
+
+// drawTime picks a moment in [from, to], following settings.seasonality when set.
+// Weights are applied by rejection sampling, so the range is always respected.
+func drawTime(from, to time.Time) time.Time {
+	s := Settings.Seasonality
+	if len(s.Months) == 0 && len(s.Weekdays) == 0 && len(s.Hours) == 0 {
+		return gofakeit.DateRange(from, to)
+	}
+	max := maxWeight(s.Months) * maxWeight(s.Weekdays) * maxWeight(s.Hours)
+	var t time.Time
+	for i := 0; i < 100; i++ {
+		t = gofakeit.DateRange(from, to).In(dateLocation())
+		w := weightAt(s.Months, int(t.Month())-1) *
+			weightAt(s.Weekdays, (int(t.Weekday())+6)%7) *
+			weightAt(s.Hours, t.Hour())
+		if max <= 0 || seededRand.Float64()*max < w {
+			break
+		}
+	}
+	return t
+}
+
+func maxWeight(ws []float64) float64 {
+	if len(ws) == 0 {
+		return 1
+	}
+	m := 0.0
+	for _, w := range ws {
+		if w > m {
+			m = w
+		}
+	}
+	return m
+}
+
+func weightAt(ws []float64, i int) float64 {
+	if len(ws) == 0 {
+		return 1
+	}
+	if i >= len(ws) {
+		return 0
+	}
+	return ws[i]
+}
+
+// isTimezoneType reports whether the column stores an offset (timestamptz, datetimeoffset,
+// Oracle TIMESTAMP WITH [LOCAL] TIME ZONE).
+func isTimezoneType(dataType string) bool {
+	t := strings.ToLower(dataType)
+	return strings.Contains(t, "time zone") || strings.Contains(t, "timestamptz") ||
+		strings.Contains(t, "timetz") || strings.Contains(t, "datetimeoffset")
+}
+
+// formatTime renders a generated moment for the column type in settings.timezone.
+// Offset-aware types carry the offset so the DB stores the intended instant.
+func formatTime(t time.Time, dataType string) string {
+	t = t.In(dateLocation())
+	dt := strings.ToLower(dataType)
+	switch {
+	case dt == "date":
+		return t.Format("2006-01-02")
+	case dt == "time":
+		return t.Format("15:04:05")
+	case strings.HasPrefix(dt, "time with") || dt == "timetz":
+		return t.Format("15:04:05-07:00")
+	case strings.Contains(dt, "datetimeoffset"):
+		return t.Format("2006-01-02 15:04:05 -07:00")
+	case isTimezoneType(dt):
+		return t.Format("2006-01-02 15:04:05-07:00")
+	}
+	// datetime, timestamp 등
+	return t.Format("2006-01-02 15:04:05")
+}
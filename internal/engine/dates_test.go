@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/schema"
+)
+
+func TestDateWindowFor_FollowsTimezoneChange(t *testing.T) {
+	defer func() { Settings = DefaultConfig() }()
+	Settings.DateRange = "2024-01-01..2024-01-31"
+	col := &schema.Column{Name: "created_at", DataType: "datetime"}
+
+	// 같은 범위라도 시간대가 바뀌면 다시 해석해야 함
+	Settings.Timezone = "Asia/Seoul"
+	seoul, _ := dateWindowFor(col, "orders")
+	Settings.Timezone = "America/New_York"
+	newYork, _ := dateWindowFor(col, "orders")
+
+	if seoul.Equal(newYork) {
+		t.Fatalf("2024-01-01 in Seoul and New York both start at %v", seoul)
+	}
+	if newYork.Location().String() != "America/New_York" || newYork.Hour() != 0 {
+		t.Errorf("New York window starts at %v", newYork)
+	}
+}
+
+func TestValidateSeasonality(t *testing.T) {
+	// 8~12월이 빠진 7개짜리 월 가중치는 해당 월에 날짜가 전혀 생기지 않으므로 거부
+	if err := ValidateSeasonality(SeasonalityConfig{Months: []float64{1, 1, 1, 1, 1, 1, 1}}); err == nil {
+		t.Error("7 month weights accepted")
+	}
+	if err := ValidateSeasonality(SeasonalityConfig{Hours: make([]float64, 25)}); err == nil {
+		t.Error("25 hour weights accepted")
+	}
+	ok := SeasonalityConfig{Months: make([]float64, 12), Weekdays: make([]float64, 7), Hours: make([]float64, 24)}
+	if err := ValidateSeasonality(ok); err != nil {
+		t.Errorf("complete lists rejected: %v", err)
+	}
+	if err := ValidateSeasonality(SeasonalityConfig{}); err != nil {
+		t.Errorf("empty lists rejected: %v", err)
+	}
+}
+
+func TestFormatTime_OracleTimezoneColumn(t *testing.T) {
+	defer func() { Settings = DefaultConfig() }()
+	Settings.Timezone = "Asia/Seoul"
+	at := time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC)
+
+	// 정규화 뒤에도 오프셋을 붙여야 Oracle이 의도한 시각으로 저장함
+	oracle := dialect.GetDialect("oracle")
+	for _, raw := range []string{"TIMESTAMP(6) WITH TIME ZONE", "TIMESTAMP(6) WITH LOCAL TIME ZONE"} {
+		if got := formatTime(at, oracle.NormalizeType(raw)); got != "2024-03-01 09:30:00+09:00" {
+			t.Errorf("%s: got %s", raw, got)
+		}
+	}
+	if got := formatTime(at, oracle.NormalizeType("TIMESTAMP(6)")); got != "2024-03-01 09:30:00" {
+		t.Errorf("TIMESTAMP(6): got %s", got)
+	}
+}
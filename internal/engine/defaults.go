@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"strings"
+
+	"db-pump/internal/schema"
+)
+
+// DefaultsConfig decides how often columns that declare a DEFAULT are left to the database.
+// Probability applies to every such column; Columns override it per column.
+type DefaultsConfig struct {
+	Probability float64               `mapstructure:"probability"`
+	Columns     []DefaultColumnConfig `mapstructure:"columns"`
+}
+
+// DefaultColumnConfig overrides the DEFAULT probability for one column.
+type DefaultColumnConfig struct {
+	Table       string  `mapstructure:"table"` // optional; empty matches the column in any table
+	Column      string  `mapstructure:"column"`
+	Probability float64 `mapstructure:"probability"`
+}
+
+// dbDefault is returned instead of a value when the column should take its DB DEFAULT.
+// The pumper drops such columns from the INSERT for that row.
+type dbDefault struct{}
+
+var useDBDefault = dbDefault{}
+
+// isDBDefault reports whether v asks for the column's DB DEFAULT.
+func isDBDefault(v interface{}) bool {
+	_, ok := v.(dbDefault)
+	return ok
+}
+
+// shouldUseDefault rolls the configured probability for a column with a DEFAULT.
+// Keys are never defaulted: a repeated default would collide on PK/UNIQUE.
+func shouldUseDefault(col *schema.Column, tableName string) bool {
+	if !col.HasDefault || col.IsPK || col.IsUnique {
+		return false
+	}
+	p := Settings.Defaults.Probability
+	for _, c := range Settings.Defaults.Columns {
+		if strings.EqualFold(c.Column, col.Name) && (c.Table == "" || strings.EqualFold(c.Table, tableName)) {
+			p = c.Probability
+			break
+		}
+	}
+	return p > 0 && seededRand.Float64() < p
+}
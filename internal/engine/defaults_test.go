@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"testing"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/schema"
+)
+
+func TestShouldUseDefault(t *testing.T) {
+	defer func() { Settings = DefaultConfig() }()
+	Settings.Defaults.Probability = 1
+	Settings.Defaults.Columns = []DefaultColumnConfig{{Table: "users", Column: "status", Probability: 0}}
+
+	for _, tc := range []struct {
+		col  *schema.Column
+		want bool
+	}{
+		{&schema.Column{Name: "created_at", HasDefault: true}, true},
+		{&schema.Column{Name: "created_at"}, false},                             // DEFAULT 없음
+		{&schema.Column{Name: "id", HasDefault: true, IsPK: true}, false},       // 키는 기본값을 쓰지 않음
+		{&schema.Column{Name: "code", HasDefault: true, IsUnique: true}, false}, // UNIQUE도 마찬가지
+		{&schema.Column{Name: "status", HasDefault: true}, false},               // 컬럼별 설정
+	} {
+		if got := shouldUseDefault(tc.col, "users"); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.col.Name, got, tc.want)
+		}
+	}
+}
+
+func TestWithoutDefaults(t *testing.T) {
+	d := dialect.GetDialect("postgres")
+	cols := []*schema.Column{
+		{Name: "status", DataType: "varchar", Length: 10, HasDefault: true},
+		{Name: "note", DataType: "varchar", Length: 20},
+	}
+	cache := make(map[string]string)
+
+	q, args := withoutDefaults(d, "users", cols, []interface{}{useDBDefault, "memo"}, cache)
+	if want := `INSERT INTO "users" ("note") VALUES ($1) ON CONFLICT DO NOTHING`; q != want || len(args) != 1 || args[0] != "memo" {
+		t.Errorf("got %s %v, want %s [memo]", q, args, want)
+	}
+
+	// 모든 컬럼이 기본값이어도 빈 컬럼 목록을 만들지 않음
+	q, args = withoutDefaults(d, "users", cols, []interface{}{useDBDefault, useDBDefault}, cache)
+	if want := `INSERT INTO "users" ("status") VALUES ($1) ON CONFLICT DO NOTHING`; q != want || len(args) != 1 || args[0] == nil {
+		t.Errorf("got %s %v, want %s with a generated status", q, args, want)
+	}
+}
@@ -0,0 +1,258 @@
+package engine
+
+import (
+	"bytes"
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Larger default datasets (names with real-world frequencies) shipped inside the binary.
+//
+//go:embed data
+var embeddedData embed.FS
+
+// Dictionary is a list of values to pick from, optionally weighted (e.g. surname
+// frequencies) so that large tables get a realistic value distribution.
+type Dictionary struct {
+	Values []string
+	cum    []float64 // cumulative weights; nil means uniform
+}
+
+// NewDictionary returns an unweighted dictionary.
+func NewDictionary(values []string) *Dictionary {
+	return &Dictionary{Values: values}
+}
+
+// Len returns the number of values (0 for a nil dictionary).
+func (d *Dictionary) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.Values)
+}
+
+// Pick returns a random value, honouring weights.
+func (d *Dictionary) Pick() string {
+	if d.cum == nil {
+		return d.Values[seededRand.Intn(len(d.Values))]
+	}
+	r := seededRand.Float64() * d.cum[len(d.cum)-1]
+	return d.Values[sort.SearchFloat64s(d.cum, r)]
+}
+
+func (d *Dictionary) add(value string, weight float64) {
+	if weight <= 0 {
+		return
+	}
+	total := 0.0
+	if n := len(d.cum); n > 0 {
+		total = d.cum[n-1]
+	}
+	d.Values = append(d.Values, value)
+	d.cum = append(d.cum, total+weight)
+}
+
+// mergeDictionaries concatenates dictionaries; unweighted values count as weight 1.
+func mergeDictionaries(ds ...*Dictionary) *Dictionary {
+	m := &Dictionary{}
+	for _, d := range ds {
+		for i, v := range d.Values {
+			w := 1.0
+			if d.cum != nil {
+				w = d.cum[i]
+				if i > 0 {
+					w -= d.cum[i-1]
+				}
+			}
+			m.add(v, w)
+		}
+	}
+	if isUniform(m.cum) {
+		m.cum = nil
+	}
+	return m
+}
+
+// dictionaryNames are the keys accepted under settings.dictionaries.
+var dictionaryNames = []string{"last_names", "first_names", "first_names_male", "first_names_female", "cities", "districts", "streets", "words"}
+
+// loadedDictionaries holds the files configured under settings.dictionaries.
+// They replace the matching list of whichever locale is active.
+var loadedDictionaries = make(map[string]*Dictionary)
+
+// LoadDictionaries reads the dictionary files configured in settings.dictionaries,
+// e.g. {"last_names": "./dicts/surnames.csv"}.
+func LoadDictionaries(files map[string]string) error {
+	loaded := make(map[string]*Dictionary)
+	for name, path := range files {
+		if !isDictionaryName(name) {
+			return fmt.Errorf("unknown dictionary %q (supported: %s)", name, strings.Join(dictionaryNames, ", "))
+		}
+		d, err := LoadDictionaryFile(path)
+		if err != nil {
+			return fmt.Errorf("dictionary %s: %w", name, err)
+		}
+		loaded[name] = d
+	}
+	loadedDictionaries = loaded
+	beginRow() // a persona drawn from the previous dictionaries must not carry over
+	return nil
+}
+
+func isDictionaryName(name string) bool {
+	for _, n := range dictionaryNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadDictionaryFile reads a dictionary; the format follows the extension:
+//   - .txt: one value per line ('#' starts a comment line)
+//   - .csv: value[,weight] per row; a header row ("value,weight", "name") is skipped
+//   - .yaml/.yml: a list of values, or a map of value → weight
+func LoadDictionaryFile(path string) (*Dictionary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseDictionary(data, filepath.Ext(path))
+}
+
+func parseDictionary(data []byte, ext string) (*Dictionary, error) {
+	d := &Dictionary{}
+	switch strings.ToLower(ext) {
+	case ".csv":
+		r := csv.NewReader(bytes.NewReader(data))
+		r.FieldsPerRecord = -1
+		r.Comment = '#'
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		for i, rec := range records {
+			value := strings.TrimSpace(rec[0])
+			if i == 0 && isCSVHeader(rec) {
+				continue
+			}
+			weight := 1.0
+			if len(rec) > 1 {
+				w, err := strconv.ParseFloat(strings.TrimSpace(rec[1]), 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid weight %q", i+1, rec[1])
+				}
+				weight = w
+			}
+			if value != "" {
+				d.add(value, weight)
+			}
+		}
+	case ".yaml", ".yml":
+		var list []string
+		if err := yaml.Unmarshal(data, &list); err == nil {
+			for _, v := range list {
+				d.add(v, 1)
+			}
+			break
+		}
+		var weighted map[string]float64
+		if err := yaml.Unmarshal(data, &weighted); err != nil {
+			return nil, fmt.Errorf("expected a list of values or a map of value: weight: %w", err)
+		}
+		keys := make([]string, 0, len(weighted))
+		for k := range weighted {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			d.add(k, weighted[k])
+		}
+	default: // .txt and anything else: plain lines
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				d.add(line, 1)
+			}
+		}
+	}
+
+	if len(d.Values) == 0 {
+		return nil, fmt.Errorf("no values")
+	}
+	if isUniform(d.cum) {
+		d.cum = nil
+	}
+	return d, nil
+}
+
+// csvHeaderNames are the column names recognized as the header of a single-column CSV.
+var csvHeaderNames = []string{"value", "name", "names", "word", "words", "city", "district", "street",
+	"last_name", "first_name", "surname", "lastname", "firstname"}
+
+// isCSVHeader reports whether the first CSV row is a header: its weight isn't a number
+// ("value,weight"), or it is a single column named like one (value, name, word...).
+func isCSVHeader(rec []string) bool {
+	if len(rec) > 1 {
+		_, err := strconv.ParseFloat(strings.TrimSpace(rec[1]), 64)
+		return err != nil
+	}
+	name := strings.ToLower(strings.TrimSpace(rec[0]))
+	for _, h := range csvHeaderNames {
+		if name == h {
+			return true
+		}
+	}
+	return false
+}
+
+// isUniform reports whether every weight is the same (cum is then just 1, 2, 3, ...).
+func isUniform(cum []float64) bool {
+	for i := 1; i < len(cum); i++ {
+		if cum[i]-cum[i-1] != cum[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// embeddedDictionary loads one of the shipped datasets under data/.
+// The files are part of the binary, so a failure is a build mistake.
+func embeddedDictionary(path string) *Dictionary {
+	data, err := embeddedData.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	d, err := parseDictionary(data, filepath.Ext(path))
+	if err != nil {
+		panic(fmt.Sprintf("%s: %v", path, err))
+	}
+	return d
+}
+
+// embeddedReadings loads a shipped "text,romanization" table (e.g. data/ja/romaji.csv).
+func embeddedReadings(path string) map[string]string {
+	data, err := embeddedData.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("%s: %v", path, err))
+	}
+	readings := make(map[string]string, len(records))
+	for _, rec := range records[1:] { // header
+		readings[rec[0]] = rec[1]
+	}
+	return readings
+}
@@ -0,0 +1,71 @@
+package engine_test
+
+import (
+	"db-pump/internal/engine"
+	"db-pump/internal/schema"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadDictionaryFile_Formats(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"plain.txt":     "# 주석\n김\n이\n\n박\n",
+		"weighted.csv":  "name,weight\n김,99\n이,1\n",
+		"single.csv":    "name\n김\n이\n박\n", // 헤더만 있는 단일 컬럼
+		"noheader.csv":  "김\n이\n",
+		"list.yaml":     "- 김\n- 이\n- 박\n",
+		"weighted.yaml": "김: 99\n이: 1\n",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, want := range map[string]int{"plain.txt": 3, "weighted.csv": 2, "single.csv": 3, "noheader.csv": 2, "list.yaml": 3, "weighted.yaml": 2} {
+		d, err := engine.LoadDictionaryFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if d.Len() != want {
+			t.Errorf("%s: %d values, want %d", name, d.Len(), want)
+		}
+	}
+
+	// 가중치 99:1 이면 대부분 "김"이 나와야 한다
+	d, _ := engine.LoadDictionaryFile(filepath.Join(dir, "weighted.csv"))
+	kim := 0
+	for i := 0; i < 1000; i++ {
+		if d.Pick() == "김" {
+			kim++
+		}
+	}
+	if kim < 950 {
+		t.Errorf("weighted pick returned 김 %d/1000 times", kim)
+	}
+}
+
+func TestLoadDictionaries_OverridesLocale(t *testing.T) {
+	defer engine.LoadDictionaries(nil)
+
+	path := filepath.Join(t.TempDir(), "surnames.txt")
+	if err := os.WriteFile(path, []byte("독고\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.LoadDictionaries(map[string]string{"last_names": path}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		v := engine.GenerateValue(&schema.Column{Name: "name", DataType: "varchar", Meaning: "name"}, "t").(string)
+		if !strings.HasPrefix(v, "독고") {
+			t.Fatalf("name %q does not use the configured surname dictionary", v)
+		}
+	}
+
+	if err := engine.LoadDictionaries(map[string]string{"surnames": path}); err == nil {
+		t.Error("expected an error for an unknown dictionary name")
+	}
+}
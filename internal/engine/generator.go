@@ -3,8 +3,8 @@ package engine
 import (
 	"db-pump/internal/schema"
 	"fmt"
+	"math"
 	"math/rand"
-	"strconv"
 	"strings"
 	"time"
 
@@ -24,7 +24,7 @@ func init() {
 	}
 }
 
-// 1. 영문 텍스트 생성 (사전에 있는 단어 위주로)
+// 영문 텍스트 생성 (사전에 있는 단어 위주로, tsvector 등 언어 무관 컬럼용)
 func generateEnglishText(wordCount int) string {
 	var words []string
 	for i := 0; i < wordCount; i++ {
@@ -33,46 +33,129 @@ func generateEnglishText(wordCount int) string {
 	return strings.Join(words, " ")
 }
 
-// 2. 번역 함수 (영 -> 한)
-func translateToKorean(text string) string {
-	var result []string
-	words := strings.Split(text, " ")
-	for _, w := range words {
-		if val, ok := EngToKorMap[w]; ok {
-			result = append(result, val)
-		} else {
-			result = append(result, w) // 사전에 없으면 그대로
-		}
+func truncate(s string, limit int) string {
+	if limit <= 0 {
+		return s
 	}
-	return strings.Join(result, " ")
+	runes := []rune(s)
+	if len(runes) > limit {
+		return string(runes[:limit])
+	}
+	return s
 }
 
-// 한국어 고유 데이터 생성 함수들
-func GenerateKoreanName() string {
-	return LastNames[seededRand.Intn(len(LastNames))] + FirstNames[seededRand.Intn(len(FirstNames))]
+// generateSetValue picks a non-empty subset of a MySQL SET definition,
+// keeping definition order, e.g. "Trailers,Deleted Scenes".
+func generateSetValue(members []string) string {
+	var picked []string
+	for _, m := range members {
+		if seededRand.Intn(2) == 0 {
+			picked = append(picked, m)
+		}
+	}
+	if len(picked) == 0 {
+		picked = append(picked, members[seededRand.Intn(len(members))])
+	}
+	return strings.Join(picked, ",")
 }
 
-func GenerateKoreanAddress() string {
-	city := Cities[seededRand.Intn(len(Cities))]
-	district := Districts[seededRand.Intn(len(Districts))]
-	street := Streets[seededRand.Intn(len(Streets))]
-	number := seededRand.Intn(100) + 1
-	return fmt.Sprintf("%s %s %s %d번길", city, district, street, number)
+// generateArrayLiteral builds a PostgreSQL array literal such as {"Trailers","Deleted Scenes"}.
+// Elements are generated as if they were a column of ElementType; the element count
+// comes from Settings.Array.
+func generateArrayLiteral(col *schema.Column, tableName string) string {
+	elem := *col
+	elem.DataType = col.ElementType
+	elem.ElementType = ""
+	elem.Length = 0
+
+	lo, hi := Settings.Array.MinLength, Settings.Array.MaxLength
+	if lo < 0 {
+		lo = 0
+	}
+	if hi < lo {
+		hi = lo
+	}
+	n := lo + seededRand.Intn(hi-lo+1)
+
+	items := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		v := GenerateValue(&elem, tableName)
+		if v == nil {
+			items = append(items, "NULL")
+			continue
+		}
+		s := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(fmt.Sprintf("%v", v))
+		items = append(items, `"`+s+`"`)
+	}
+	return "{" + strings.Join(items, ",") + "}"
 }
 
-func GenerateKoreanPhone() string {
-	return fmt.Sprintf("010-%04d-%04d", seededRand.Intn(10000), seededRand.Intn(10000))
+// maxDecimalIntDigits caps the integer digits used for DECIMAL generation so that
+// wide columns such as DECIMAL(18,4) get realistic amounts instead of 10^14.
+const maxDecimalIntDigits = 6
+
+// generateDecimal returns a value that fits the declared DECIMAL(p,s).
+// Without a declared precision (unconstrained NUMERIC) it falls back to price-like values,
+// as do FLOAT and DOUBLE, whose precision counts binary digits and which have no scale.
+func generateDecimal(col *schema.Column) float64 {
+	dataType := strings.ToLower(col.DataType)
+	isFixed := strings.Contains(dataType, "decimal") || strings.Contains(dataType, "numeric")
+	if !isFixed || col.Precision <= 0 || col.Scale < 0 || col.Scale > col.Precision {
+		lo, hi := checkBounds(col, 0.99, 99.99, 0.01)
+		return gofakeit.Price(lo, hi)
+	}
+
+	intDigits := col.Precision - col.Scale
+	if intDigits > maxDecimalIntDigits {
+		intDigits = maxDecimalIntDigits
+	}
+	step := math.Pow10(-col.Scale)
+	hi := math.Pow10(intDigits) - step // DECIMAL(3,2) → 9.99, DECIMAL(5,0) → 99999
+	lo, hi := checkBounds(col, 0, hi, step)
+
+	v := lo + seededRand.Float64()*(hi-lo)
+	pow := math.Pow10(col.Scale)
+	v = math.Round(v*pow) / pow
+	return math.Min(math.Max(v, lo), hi)
 }
 
-func truncate(s string, limit int) string {
-	if limit <= 0 {
-		return s
+// checkBounds narrows the default range [lo, hi] to the column's CHECK range.
+// step is the smallest representable increment (1 for integers, 0.01 for prices)
+// and is used to turn exclusive bounds (>, <) into inclusive ones.
+// If the default range lies entirely outside the CHECK window, a window of the
+// same width is placed against the CHECK bound instead.
+func checkBounds(col *schema.Column, lo, hi, step float64) (float64, float64) {
+	if col.Check == nil || (col.Check.Min == nil && col.Check.Max == nil) {
+		return lo, hi
 	}
-	runes := []rune(s)
-	if len(runes) > limit {
-		return string(runes[:limit])
+	width := hi - lo
+	cLo, cHi := math.Inf(-1), math.Inf(1)
+	if col.Check.Min != nil {
+		cLo = *col.Check.Min
+		if col.Check.MinExclusive {
+			cLo += step
+		}
+		cLo = math.Ceil(cLo/step) * step
 	}
-	return s
+	if col.Check.Max != nil {
+		cHi = *col.Check.Max
+		if col.Check.MaxExclusive {
+			cHi -= step
+		}
+		cHi = math.Floor(cHi/step) * step
+	}
+
+	nLo, nHi := math.Max(lo, cLo), math.Min(hi, cHi)
+	if nLo > nHi {
+		nLo, nHi = cLo, cHi
+		if math.IsInf(nLo, -1) {
+			nLo = nHi - width
+		}
+		if math.IsInf(nHi, 1) {
+			nHi = nLo + width
+		}
+	}
+	return nLo, nHi
 }
 
 // GenerateValue generates a random value based on column definition
@@ -80,16 +163,37 @@ func GenerateValue(col *schema.Column, tableName string) interface{} {
 	dataType := strings.ToLower(col.DataType)
 	colName := strings.ToLower(col.Name)
 	meaning := col.Meaning
+	loc := activeLocale() // settings.language
 
-	// 0. ENUM / CHECK 처리
-	if strings.Contains(colName, "special_features") || strings.Contains(colName, "features") ||
-		strings.Contains(colName, "rating") {
-		if col.IsNullable {
-			return nil
-		}
-		return ""
+	// 0. 배열 타입 (PostgreSQL text[], int[] ...)
+	if col.ElementType != "" {
+		return generateArrayLiteral(col, tableName)
+	}
+
+	// 0. 파티션 키 (LIST 값 / 숫자 RANGE; 날짜 키는 날짜 구간에서 처리)
+	if v, ok := generatePartitionValue(col); ok {
+		return v
+	}
+
+	// 0. 공간 타입 / 위도·경도 (같은 행의 컬럼끼리 같은 위치를 공유)
+	if v, ok := generateSpatial(col); ok {
+		return v
+	}
+
+	// 0. UUID / JSON / inet / interval / xml 등 특수 타입
+	if v, ok := generateSpecialType(col, tableName); ok {
+		return v
 	}
+
+	// 0. ENUM / SET / CHECK 처리
+	if dataType == "set" && len(col.EnumValues) > 0 {
+		return generateSetValue(col.EnumValues)
+	}
+	person := personColumn(colName, meaning) // 같은 행의 이름/이메일/성별/생년월일은 한 사람을 기준으로 생성
 	if len(col.EnumValues) > 0 {
+		if person == personGender {
+			return rowPersona().genderValue(col, false)
+		}
 		return col.EnumValues[seededRand.Intn(len(col.EnumValues))]
 	}
 
@@ -100,33 +204,69 @@ func GenerateValue(col *schema.Column, tableName string) interface{} {
 
 		isID := strings.HasSuffix(colName, "id") || strings.HasSuffix(colName, "_id")
 
+		// CHECK (col LIKE 'PFX%')
+		if col.Check != nil && col.Check.Prefix != "" {
+			return truncate(fmt.Sprintf("%s%d", col.Check.Prefix, seededRand.Intn(100000)), col.Length)
+		}
+
+		// 주민등록번호 / 사업자등록번호 / 법인등록번호 / 계좌번호 / 카드번호
+		if kind := identifierKind(meaning); kind != "" {
+			return generateIdentifier(kind, col)
+		}
+
+		// 비밀번호 해시 (settings.password) / API 키·토큰
+		if isPasswordColumn(meaning) {
+			return generatePassword(col, tableName)
+		}
+		if !isID && isSecretColumn(colName) {
+			return generateSecret(col)
+		}
+
+		// 행 단위 인물 (이름, 이메일, 아이디, 성별, 생년월일)
+		if !isID || person == personUsername {
+			switch person {
+			case personEmail:
+				return truncate(rowPersona().email(), col.Length)
+			case personUsername:
+				return truncate(rowPersona().username(), col.Length)
+			case personFirstName:
+				return truncate(rowPersona().firstName, col.Length)
+			case personLastName:
+				return truncate(rowPersona().lastName, col.Length)
+			case personGender:
+				return rowPersona().genderValue(col, false)
+			case personBirth:
+				if strings.Contains(meaning, "year") {
+					return fmt.Sprintf("%d", rowPersona().birthDate(col, tableName).Year())
+				}
+				return birthString(rowPersona().birthDate(col, tableName), col)
+			}
+		}
+
 		// Meaning 기반 생성
 		if strings.Contains(meaning, "year") || strings.Contains(colName, "year") {
 			// year는 ID 여부 상관없이 값(연도) 생성
 			return fmt.Sprintf("%d", 2000+seededRand.Intn(26))
 		}
 		if !isID && (strings.Contains(meaning, "phone") || strings.Contains(colName, "phone")) {
-			return truncate(GenerateKoreanPhone(), col.Length)
-		}
-		if !isID && (strings.Contains(meaning, "email") || strings.Contains(colName, "email")) {
-			return truncate(gofakeit.Email(), col.Length)
+			return truncate(loc.Phone(), col.Length)
 		}
 		if !isID && (strings.Contains(meaning, "name") || strings.Contains(colName, "name") ||
 			strings.Contains(colName, "first") || strings.Contains(colName, "last")) {
 			if col.Length > 0 && col.Length < 3 {
 				// 짧은 이름 (성만)
-				return truncate(string([]rune(LastNames[seededRand.Intn(len(LastNames))])), col.Length)
+				return truncate(rowPersona().lastName, col.Length)
 			}
-			return truncate(GenerateKoreanName(), col.Length)
+			return truncate(rowPersona().fullName(), col.Length)
 		}
 		if !isID && (strings.Contains(meaning, "address") || strings.Contains(colName, "address")) {
 			if strings.Contains(colName, "2") {
-				return truncate(fmt.Sprintf("%d층 %d호", seededRand.Intn(20)+1, seededRand.Intn(10)+1), col.Length)
+				return truncate(loc.AddressDetail(), col.Length)
 			}
-			return truncate(GenerateKoreanAddress(), col.Length)
+			return truncate(loc.Address(), col.Length)
 		}
 		if strings.Contains(meaning, "zipcode") || strings.Contains(colName, "zip") || strings.Contains(colName, "postal") {
-			return fmt.Sprintf("%05d", seededRand.Intn(100000))
+			return truncate(loc.PostalCode(), col.Length)
 		}
 		if strings.Contains(meaning, "yesno") || strings.Contains(colName, "active") || strings.Contains(colName, "is_") {
 			// 문자열 'Y'/'N' 생성
@@ -136,78 +276,45 @@ func GenerateValue(col *schema.Column, tableName string) interface{} {
 			return "N"
 		}
 		if !isID && (strings.Contains(meaning, "title") || strings.Contains(meaning, "subject")) {
-			eng := generateEnglishText(2)
-			kor := translateToKorean(eng)
-			return truncate(kor, col.Length)
+			return generateTitle(col, tableName) // settings.text 모델 또는 언어별 내장 말뭉치
 		}
 		if !isID && (strings.Contains(meaning, "description") || strings.Contains(meaning, "content") ||
 			strings.Contains(meaning, "comment") || strings.Contains(meaning, "text")) {
-			eng := generateEnglishText(10)
-			kor := translateToKorean(eng)
-			return truncate(kor, col.Length)
+			return generateText(col, tableName, 100)
 		}
 		if !isID && (strings.Contains(meaning, "country") || strings.Contains(colName, "country")) {
-			return "대한민국"
+			return truncate(loc.Country, col.Length)
 		}
 		if !isID && (strings.Contains(meaning, "city") || strings.Contains(colName, "city")) {
-			return truncate(Cities[seededRand.Intn(len(Cities))], col.Length)
+			return truncate(loc.City(), col.Length)
 		}
 		if !isID && (strings.Contains(meaning, "district") || strings.Contains(colName, "district")) {
-			return truncate(Districts[seededRand.Intn(len(Districts))], col.Length)
+			return truncate(loc.District(), col.Length)
 		}
 
 		// (Meaning 미발견 시) 일반 텍스트 데이터 생성
 
 		// Language/Category (테이블명 의존)
 		if tableName == "language" || tableName == "category" {
-			eng := generateEnglishText(1)
-			kor := translateToKorean(eng)
-			return truncate(fmt.Sprintf("%s-%d", kor, seededRand.Intn(1000)), col.Length)
+			return truncate(fmt.Sprintf("%s-%d", loc.Word(), seededRand.Intn(1000)), col.Length)
 		}
 
 		// 기본 텍스트
 		if col.Length > 0 && col.Length < 20 {
-			eng := generateEnglishText(1)
-			kor := translateToKorean(eng)
-			return truncate(kor, col.Length)
+			return truncate(loc.Word(), col.Length)
 		}
-		eng := generateEnglishText(5)
-		kor := translateToKorean(eng)
-		return truncate(kor, col.Length)
+		return truncate(loc.Text(5), col.Length)
 	}
 
 	// 2. 숫자, 날짜 등 나머지 타입 처리 (Meaning 무시하고 타입 위주로 생성)
 
 	// 2.1 날짜/시간 타입 (주의: MSSQL 호환성을 위해 포맷팅된 문자열 반환)
 	if strings.Contains(dataType, "date") || strings.Contains(dataType, "time") {
-		// PostgreSQL Partitioned Table Support (payment_pYYYY_MM)
-		if strings.HasPrefix(tableName, "payment_p") {
-			parts := strings.Split(tableName, "_p")
-			if len(parts) == 2 {
-				dateParts := strings.Split(parts[1], "_")
-				if len(dateParts) >= 2 {
-					year, err1 := strconv.Atoi(dateParts[0])
-					month, err2 := strconv.Atoi(dateParts[1])
-					if err1 == nil && err2 == nil {
-						start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-						// 해당 월의 마지막 날 (다음달 1일 - 1초)
-						end := start.AddDate(0, 1, 0).Add(-time.Second)
-						val := gofakeit.DateRange(start, end)
-						return val.Format("2006-01-02 15:04:05")
-					}
-				}
-			}
-		}
-
-		val := gofakeit.DateRange(time.Now().AddDate(-1, 0, 0), time.Now())
-		if dataType == "date" { // 정확히 date인 경우
-			return val.Format("2006-01-02")
-		}
-		if dataType == "time" { // 정확히 time인 경우
-			return val.Format("15:04:05")
+		if person == personBirth {
+			return formatTime(rowPersona().birthDate(col, tableName), dataType)
 		}
-		// datetime, timestamp 등
-		return val.Format("2006-01-02 15:04:05")
+		// settings.date_range / timezone / seasonality, 행 내 시간 순서, 파티션 범위 반영
+		return formatTime(generateTime(col, tableName), dataType)
 	}
 
 	// 2.2 숫자 타입
@@ -218,38 +325,31 @@ func GenerateValue(col *schema.Column, tableName string) interface{} {
 			return seededRand.Intn(2) // 0 or 1
 		}
 
-		if strings.Contains(dataType, "tinyint") {
-			return gofakeit.Number(0, 127) // Safe range for signed/unsigned logic simplicity
-		}
-		if strings.Contains(dataType, "smallint") {
-			return gofakeit.Number(1, 30000)
-		}
-		// year 컬럼이 int일 경우 여기서 처리
-		if strings.Contains(colName, "year") || strings.Contains(meaning, "year") {
-			return 2000 + seededRand.Intn(26)
+		switch person {
+		case personGender:
+			return rowPersona().genderValue(col, true)
+		case personAge:
+			return rowPersona().age()
+		case personBirth: // birth_year
+			if strings.Contains(meaning, "year") {
+				return rowPersona().birthDate(col, tableName).Year()
+			}
 		}
 
-		// Respect column length (precision) if available
-		maxVal := 50000
-		if col.Length > 0 && col.Length < 10 { // Only apply for reasonable small precisions
-			limit := 1
-			for i := 0; i < col.Length; i++ {
-				limit *= 10
-			}
-			limit -= 1
-			if limit < maxVal {
-				maxVal = limit
-				if maxVal < 1 {
-					maxVal = 9 // Minimum fallback
-				}
-			}
+		// year 컬럼이 int일 경우 여기서 처리 (연도를 담을 수 있는 타입만)
+		if _, typeMax := intTypeRange(dataType, col.Unsigned); typeMax >= 2025 &&
+			(strings.Contains(colName, "year") || strings.Contains(meaning, "year")) {
+			lo, hi := checkBounds(col, 2000, 2025, 1)
+			return gofakeit.Number(int(lo), int(hi))
 		}
-		return gofakeit.Number(1, maxVal)
+
+		// 타입/자릿수/CHECK 범위를 반영 (settings.integer_range: realistic | full)
+		return generateInt(col)
 	}
 
 	if strings.Contains(dataType, "decimal") || strings.Contains(dataType, "numeric") ||
 		strings.Contains(dataType, "float") || strings.Contains(dataType, "double") {
-		return gofakeit.Price(0.99, 99.99)
+		return generateDecimal(col)
 	}
 
 	// 2.3 불린 타입
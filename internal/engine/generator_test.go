@@ -0,0 +1,325 @@
+package engine_test
+
+import (
+	"db-pump/internal/engine"
+	"db-pump/internal/schema"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateValue_DecimalFitsPrecision(t *testing.T) {
+	// DECIMAL(3,2)는 9.99를 넘으면 안 되고, DECIMAL(18,4)는 소수 4자리까지 활용해야 한다
+	cases := []struct {
+		precision, scale int
+		max              float64
+	}{
+		{3, 2, 9.99},
+		{5, 0, 99999},
+		{18, 4, 999999.9999},
+	}
+
+	for _, c := range cases {
+		col := &schema.Column{Name: "amount", DataType: "decimal", Precision: c.precision, Scale: c.scale}
+		for i := 0; i < 500; i++ {
+			v, ok := engine.GenerateValue(col, "payment").(float64)
+			if !ok {
+				t.Fatalf("DECIMAL(%d,%d): expected float64", c.precision, c.scale)
+			}
+			if v < 0 || v > c.max {
+				t.Fatalf("DECIMAL(%d,%d): %v out of range", c.precision, c.scale, v)
+			}
+			scaled := v * math.Pow10(c.scale)
+			if math.Abs(scaled-math.Round(scaled)) > 1e-6 {
+				t.Fatalf("DECIMAL(%d,%d): %v has more than %d decimals", c.precision, c.scale, v, c.scale)
+			}
+		}
+	}
+}
+
+func TestGenerateValue_FloatIgnoresPrecision(t *testing.T) {
+	// FLOAT(53)/DOUBLE의 precision은 이진 자릿수이고 scale이 없으므로 정수만 나오면 안 된다
+	for _, dataType := range []string{"float", "double precision"} {
+		col := &schema.Column{Name: "ratio", DataType: dataType, Precision: 53}
+		fractional := false
+		for i := 0; i < 100 && !fractional; i++ {
+			v := engine.GenerateValue(col, "t").(float64)
+			fractional = v != math.Trunc(v)
+		}
+		if !fractional {
+			t.Errorf("%s: only integers generated", dataType)
+		}
+	}
+}
+
+func TestGenerateValue_ArrayLengths(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+	col := &schema.Column{Name: "tags", DataType: "ARRAY", ElementType: "text"}
+
+	for _, tc := range []struct{ min, max, wantMin, wantMax int }{
+		{1, 3, 1, 3},
+		{-2, 1, 0, 1},  // 음수 최소 길이는 0으로
+		{-5, -1, 0, 0}, // 최대가 최소보다 작으면 최소에 맞춤
+		{4, 2, 4, 4},
+	} {
+		engine.Settings.Array = engine.ArrayConfig{MinLength: tc.min, MaxLength: tc.max}
+		for i := 0; i < 50; i++ {
+			v := engine.GenerateValue(col, "film").(string)
+			n := 0
+			if v != "{}" {
+				n = strings.Count(v, `","`) + 1
+			}
+			if n < tc.wantMin || n > tc.wantMax {
+				t.Fatalf("min %d max %d: %s has %d elements", tc.min, tc.max, v, n)
+			}
+		}
+	}
+}
+
+func TestGenerateValue_IntegerRangeModes(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+
+	unsignedTiny := &schema.Column{Name: "stock", DataType: "tinyint", Unsigned: true}
+	signedBig := &schema.Column{Name: "total", DataType: "bigint"}
+
+	// realistic: 작은 값 위주
+	for i := 0; i < 500; i++ {
+		if v := engine.GenerateValue(signedBig, "t").(int64); v < 1 || v > 50000 {
+			t.Fatalf("realistic bigint out of range: %d", v)
+		}
+	}
+
+	// full: 타입의 전체 범위 (unsigned tinyint는 0~255, 128 이상도 나와야 한다)
+	engine.Settings.IntegerRange = engine.RangeFull
+	sawHigh, sawNegative := false, false
+	for i := 0; i < 2000; i++ {
+		v := engine.GenerateValue(unsignedTiny, "t").(int64)
+		if v < 0 || v > 255 {
+			t.Fatalf("unsigned tinyint out of range: %d", v)
+		}
+		sawHigh = sawHigh || v > 127
+		sawNegative = sawNegative || engine.GenerateValue(signedBig, "t").(int64) < 0
+	}
+	if !sawHigh || !sawNegative {
+		t.Errorf("full range not exercised (high=%v, negative=%v)", sawHigh, sawNegative)
+	}
+}
+
+func TestGenerateValue_ModernTypes(t *testing.T) {
+	// 알 수 없는 타입이라고 nil을 돌려주면 NOT NULL 컬럼에서 실패한다
+	for _, typ := range []string{"uuid", "uniqueidentifier", "json", "jsonb", "inet", "cidr", "macaddr", "interval", "xml", "money", "hstore"} {
+		if v := engine.GenerateValue(&schema.Column{Name: "c", DataType: typ}, "t"); v == nil {
+			t.Errorf("%s: got nil", typ)
+		}
+	}
+}
+
+func TestGenerateValue_JSONFollowsExample(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+	engine.Settings.JSON = []engine.JSONColumnConfig{
+		{Table: "users", Column: "profile", Example: `{"userName": "x", "age": 30, "tags": ["a"], "address": {"zipCode": "12345"}}`},
+	}
+
+	v := engine.GenerateValue(&schema.Column{Name: "profile", DataType: "jsonb"}, "users").(string)
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(v), &doc); err != nil {
+		t.Fatalf("invalid JSON %q: %v", v, err)
+	}
+	for _, k := range []string{"userName", "age", "tags", "address"} {
+		if _, ok := doc[k]; !ok {
+			t.Errorf("missing key %s in %s", k, v)
+		}
+	}
+	if addr, ok := doc["address"].(map[string]interface{}); !ok || addr["zipCode"] == nil {
+		t.Errorf("nested object not reproduced: %s", v)
+	}
+}
+
+func TestGenerateValue_JSONSchemaArrayBounds(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+	engine.Settings.JSON = []engine.JSONColumnConfig{
+		// 음수 minItems, maxItems < minItems도 패닉 없이 처리
+		{Column: "tags", Schema: `{"type": "array", "minItems": -3, "maxItems": 2, "items": {"type": "string"}}`},
+		{Column: "codes", Schema: `{"type": "array", "minItems": 2, "maxItems": 1, "items": {"type": "integer"}}`},
+	}
+
+	for i := 0; i < 50; i++ {
+		var tags, codes []interface{}
+		if err := json.Unmarshal([]byte(engine.GenerateValue(&schema.Column{Name: "tags", DataType: "json"}, "t").(string)), &tags); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(engine.GenerateValue(&schema.Column{Name: "codes", DataType: "json"}, "t").(string)), &codes); err != nil {
+			t.Fatal(err)
+		}
+		if len(tags) > 2 || len(codes) != 2 {
+			t.Fatalf("tags %v (0~2 items), codes %v (2 items)", tags, codes)
+		}
+	}
+}
+
+func TestGenerateValue_SpatialColumnsShareLocation(t *testing.T) {
+	lat := engine.GenerateValue(&schema.Column{Name: "lat", DataType: "decimal", Scale: 6, Meaning: "latitude"}, "address").(float64)
+	lng := engine.GenerateValue(&schema.Column{Name: "lng", DataType: "decimal", Scale: 6, Meaning: "longitude"}, "address").(float64)
+	wkt := engine.GenerateValue(&schema.Column{Name: "location", DataType: "geometry"}, "address").(string)
+
+	// 기본 bounding box (대한민국) 안에 있어야 한다
+	if lat < 33.1 || lat > 38.6 || lng < 124.6 || lng > 131.9 {
+		t.Errorf("coordinates outside default bbox: %v, %v", lat, lng)
+	}
+	if want := fmt.Sprintf("POINT(%.6f %.6f)", lng, lat); wkt != want {
+		t.Errorf("geometry %s does not match lat/lng columns (%s)", wkt, want)
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		spec     string
+		from, to time.Time
+	}{
+		{"-5y..now", time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC), now},
+		{"-1y6m..-2w", time.Date(2023, 12, 15, 12, 0, 0, 0, time.UTC), time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)},
+		{"2020-01-01..2020-12-31", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"today..+30d", time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		from, to, err := engine.ParseDateRange(c.spec, now)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		if !from.Equal(c.from) || !to.Equal(c.to) {
+			t.Errorf("%s = %v..%v, want %v..%v", c.spec, from, to, c.from, c.to)
+		}
+	}
+
+	for _, bad := range []string{"-5y", "now..-1y", "yesterday..now"} {
+		if _, _, err := engine.ParseDateRange(bad, now); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
+
+func TestGenerateValue_TimezoneAwareTypes(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+	engine.Settings.Timezone = "Asia/Seoul"
+	engine.Settings.DateRange = "2024-01-01..2024-01-31"
+	engine.Settings.Seasonality.Weekdays = []float64{0, 0, 0, 0, 0, 1, 1} // 주말만
+
+	for i := 0; i < 100; i++ {
+		v := engine.GenerateValue(&schema.Column{Name: "paid_at", DataType: "timestamp with time zone"}, "orders").(string)
+		p, err := time.Parse("2006-01-02 15:04:05-07:00", v)
+		if err != nil {
+			t.Fatalf("timestamptz value %q: %v", v, err)
+		}
+		if !strings.HasSuffix(v, "+09:00") || p.Month() != time.January || p.Year() != 2024 {
+			t.Fatalf("value %q outside date_range / timezone", v)
+		}
+		if wd := p.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			t.Fatalf("value %q is not on a weekend", v)
+		}
+	}
+
+	v := engine.GenerateValue(&schema.Column{Name: "paid_at", DataType: "datetimeoffset"}, "orders").(string)
+	if !strings.HasSuffix(v, " +09:00") {
+		t.Errorf("datetimeoffset value %q has no offset", v)
+	}
+}
+
+func TestGenerateValue_LocalePacks(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+
+	cases := []struct {
+		lang, country string
+		phone         *regexp.Regexp
+		postal        *regexp.Regexp
+	}{
+		{"ko", "대한민국", regexp.MustCompile(`^010-\d{4}-\d{4}$`), regexp.MustCompile(`^\d{5}$`)},
+		{"en", "United States", regexp.MustCompile(`^\(\d{3}\) \d{3}-\d{4}$`), regexp.MustCompile(`^\d{5}$`)},
+		{"ja", "日本", regexp.MustCompile(`^090-\d{4}-\d{4}$`), regexp.MustCompile(`^\d{3}-\d{4}$`)},
+		{"zh", "中国", regexp.MustCompile(`^13\d-\d{4}-\d{4}$`), regexp.MustCompile(`^\d{6}$`)},
+	}
+	for _, c := range cases {
+		engine.Settings.Language = c.lang
+		if v := engine.GenerateValue(&schema.Column{Name: "country", DataType: "varchar", Meaning: "country"}, "t"); v != c.country {
+			t.Errorf("%s: country = %v", c.lang, v)
+		}
+		if v := engine.GenerateValue(&schema.Column{Name: "phone", DataType: "varchar", Meaning: "phone"}, "t").(string); !c.phone.MatchString(v) {
+			t.Errorf("%s: phone = %s", c.lang, v)
+		}
+		if v := engine.GenerateValue(&schema.Column{Name: "zip", DataType: "varchar", Meaning: "zipcode"}, "t").(string); !c.postal.MatchString(v) {
+			t.Errorf("%s: postal code = %s", c.lang, v)
+		}
+		if v := engine.GenerateValue(&schema.Column{Name: "name", DataType: "varchar", Meaning: "name"}, "t").(string); v == "" {
+			t.Errorf("%s: empty name", c.lang)
+		}
+	}
+}
+
+func TestGenerateValue_PersonaColumnsAgree(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+
+	for _, lang := range []string{"ko", "ja", "zh", "en"} {
+		engine.Settings.Language = lang
+		loc, _ := engine.LookupLocale(lang)
+
+		first := engine.GenerateValue(&schema.Column{Name: "first_name", DataType: "varchar", Meaning: "first name"}, "users").(string)
+		last := engine.GenerateValue(&schema.Column{Name: "last_name", DataType: "varchar", Meaning: "last name"}, "users").(string)
+		full := engine.GenerateValue(&schema.Column{Name: "full_name", DataType: "varchar", Meaning: "full name"}, "users").(string)
+		email := engine.GenerateValue(&schema.Column{Name: "email", DataType: "varchar", Meaning: "email"}, "users").(string)
+		user := engine.GenerateValue(&schema.Column{Name: "username", DataType: "varchar", Meaning: "username"}, "users").(string)
+
+		// full_name = 성 + 이름, 이메일 = 로마자 이름 기반
+		if full != loc.JoinName(last, first) {
+			t.Errorf("%s: full name %q vs %q + %q", lang, full, last, first)
+		}
+		if !strings.HasPrefix(email, user+"@") {
+			t.Errorf("%s: email %q does not use username %q", lang, email, user)
+		}
+		if r := loc.Romanize(last, true); r == "" || !strings.Contains(user, r) {
+			t.Errorf("%s: username %q does not contain romanized %q (%q)", lang, user, last, r)
+		}
+	}
+
+	// 성별 / 생년월일 / 주민등록번호가 같은 사람을 가리켜야 한다
+	engine.Settings.Language = "ko"
+	gender := engine.GenerateValue(&schema.Column{Name: "gender", DataType: "enum", Meaning: "gender", EnumValues: []string{"U", "F", "M"}}, "users")
+	birth := engine.GenerateValue(&schema.Column{Name: "birth_date", DataType: "date", Meaning: "birth date"}, "users").(string)
+	rrn := engine.GenerateValue(&schema.Column{Name: "rrn", DataType: "varchar", Meaning: "rrn", Length: 14}, "users").(string)
+	if birth[2:4]+birth[5:7]+birth[8:10] != rrn[:6] {
+		t.Errorf("birth date %s does not match 주민등록번호 %s", birth, rrn)
+	}
+	if male := (rrn[7]-'0')%2 == 1; (gender == "M") != male {
+		t.Errorf("gender %v does not match 주민등록번호 %s", gender, rrn)
+	}
+}
+
+func TestLocale_Romanize(t *testing.T) {
+	ko, _ := engine.LookupLocale("ko")
+	ja, _ := engine.LookupLocale("ja")
+	zh, _ := engine.LookupLocale("zh")
+	cases := []struct {
+		loc    *engine.Locale
+		name   string
+		family bool
+		want   string
+	}{
+		{ko, "김", true, "kim"},
+		{ko, "민준", false, "minjun"},
+		{ko, "서연", false, "seoyeon"},
+		{ko, "한결", false, "hangyeol"},
+		{ja, "佐藤", true, "sato"},
+		{ja, "翔太", false, "shota"},
+		{zh, "欧阳", true, "ouyang"},
+		{zh, "浩然", false, "haoran"},
+	}
+	for _, c := range cases {
+		if got := c.loc.Romanize(c.name, c.family); got != c.want {
+			t.Errorf("Romanize(%q) = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
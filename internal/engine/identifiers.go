@@ -0,0 +1,209 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"db-pump/internal/schema"
+)
+
+// IdentifierConfig controls Korean identifier columns (주민등록번호, 사업자등록번호, 카드번호, ...).
+type IdentifierConfig struct {
+	Mask bool `mapstructure:"mask"` // mask the sensitive part, e.g. 900101-1******
+}
+
+// Identifier kinds, as derived from column meanings (see schema.AnalyzeMeaning).
+const (
+	IdentRRN     = "rrn"     // 주민등록번호
+	IdentBizNo   = "bizno"   // 사업자등록번호
+	IdentCorpNo  = "corpno"  // 법인등록번호
+	IdentAccount = "account" // 계좌번호
+	IdentCard    = "card"    // 카드번호
+)
+
+// identifierKind maps a column to an identifier generator, or "" if it is not one.
+func identifierKind(meaning string) string {
+	words := strings.Fields(meaning)
+	has := func(w string) bool {
+		for _, x := range words {
+			if x == w {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has(IdentRRN):
+		return IdentRRN
+	case has(IdentBizNo):
+		return IdentBizNo
+	case has(IdentCorpNo):
+		return IdentCorpNo
+	case has("card") && has("number"):
+		return IdentCard
+	case has("account") && (has("number") || has("no") || has("bank")): // not a bare "account" (login, ledger)
+		return IdentAccount
+	}
+	return ""
+}
+
+// generateIdentifier returns a structurally valid fake identifier of the given kind.
+// Hyphens are dropped when the column is too short to hold them (e.g. CHAR(13) for 주민등록번호),
+// and the digits are cut when even that doesn't fit.
+func generateIdentifier(kind string, col *schema.Column) string {
+	var v string
+	switch kind {
+	case IdentRRN:
+		p := rowPersona() // 생년월일/성별 컬럼과 일치
+		v = GenerateRRN(p.birthDate(nil, ""), p.male)
+	case IdentBizNo:
+		v = GenerateBizNo()
+	case IdentCorpNo:
+		v = GenerateCorpNo()
+	case IdentAccount:
+		v = GenerateAccountNo()
+	case IdentCard:
+		v = GenerateCardNo()
+	}
+	if Settings.Identifiers.Mask {
+		v = maskIdentifier(kind, v)
+	}
+	if col.Length > 0 && len(v) > col.Length {
+		v = strings.ReplaceAll(v, "-", "")
+	}
+	return truncate(v, col.Length) // still too long: cut rather than fail the INSERT
+}
+
+// GenerateRRN returns a 주민등록번호 "YYMMDD-GNNNNNC" for the birth date and sex.
+// G is 1/2 (1900s) or 3/4 (2000s), odd for men; C is the mod-11 check digit.
+func GenerateRRN(birth time.Time, male bool) string {
+	g := 1
+	if birth.Year() >= 2000 {
+		g = 3
+	}
+	if !male {
+		g++
+	}
+	digits := birth.Format("060102") + fmt.Sprintf("%d%05d", g, seededRand.Intn(100000))
+	weights := []int{2, 3, 4, 5, 6, 7, 8, 9, 2, 3, 4, 5}
+	sum := 0
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+	check := (11 - sum%11) % 10
+	return fmt.Sprintf("%s-%s%d", digits[:6], digits[6:], check)
+}
+
+// GenerateBizNo returns a 사업자등록번호 "XXX-XX-XXXXX" with a valid check digit.
+func GenerateBizNo() string {
+	d := randomDigits(9)
+	d[0] = 1 + seededRand.Intn(9) // 세무서 코드는 0으로 시작하지 않는다
+	weights := []int{1, 3, 7, 1, 3, 7, 1, 3, 5}
+	sum := 0
+	for i, w := range weights {
+		sum += d[i] * w
+	}
+	sum += d[8] * 5 / 10
+	check := (10 - sum%10) % 10
+	s := joinDigits(d) + fmt.Sprintf("%d", check)
+	return s[:3] + "-" + s[3:5] + "-" + s[5:]
+}
+
+// GenerateCorpNo returns a 법인등록번호 "XXXXXX-XXXXXXX" with a valid check digit.
+func GenerateCorpNo() string {
+	d := randomDigits(12)
+	sum := 0
+	for i, v := range d {
+		sum += v * (1 + i%2) // weights 1,2,1,2,...
+	}
+	check := (10 - sum%10) % 10
+	s := joinDigits(d) + fmt.Sprintf("%d", check)
+	return s[:6] + "-" + s[6:]
+}
+
+// accountFormats are common bank account layouts ('#' = digit). Korean account
+// numbers have no public checksum, so only the bank-specific grouping is reproduced.
+var accountFormats = []string{
+	"110-###-######",   // 신한
+	"######-##-######", // 국민
+	"1002-###-######",  // 우리
+	"###-######-#####", // 하나
+	"3##-####-####-##", // 농협
+	"3333-##-#######",  // 카카오뱅크
+}
+
+// GenerateAccountNo returns a bank account number in one of the common layouts.
+func GenerateAccountNo() string {
+	return fillDigits(accountFormats[seededRand.Intn(len(accountFormats))])
+}
+
+// cardPrefixes are issuer prefixes (Visa, Mastercard, BC/국내 전용).
+var cardPrefixes = []string{"4", "51", "52", "53", "54", "55", "9410", "9420"}
+
+// GenerateCardNo returns a 16-digit card number "XXXX-XXXX-XXXX-XXXX" passing the Luhn check.
+func GenerateCardNo() string {
+	prefix := cardPrefixes[seededRand.Intn(len(cardPrefixes))]
+	d := make([]int, 0, 16)
+	for _, c := range prefix {
+		d = append(d, int(c-'0'))
+	}
+	d = append(d, randomDigits(15-len(prefix))...)
+	d = append(d, luhnCheckDigit(d))
+	s := joinDigits(d)
+	return s[:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:]
+}
+
+// luhnCheckDigit returns the digit that makes digits+check pass the Luhn algorithm.
+func luhnCheckDigit(digits []int) int {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		v := digits[i]
+		if (len(digits)-i)%2 == 1 { // doubled positions, counted from the check digit
+			v *= 2
+			if v > 9 {
+				v -= 9
+			}
+		}
+		sum += v
+	}
+	return (10 - sum%10) % 10
+}
+
+// maskIdentifier hides the sensitive part the way Korean services display it.
+func maskIdentifier(kind, v string) string {
+	r := []rune(v)
+	maskFrom := func(from, to int) {
+		for i := from; i < to && i < len(r); i++ {
+			if r[i] != '-' {
+				r[i] = '*'
+			}
+		}
+	}
+	switch kind {
+	case IdentRRN: // 900101-1******
+		maskFrom(8, len(r))
+	case IdentCard: // 1234-56**-****-3456
+		maskFrom(7, len(r)-4)
+	case IdentAccount: // 110-***-***789
+		maskFrom(strings.Index(v, "-")+1, len(r)-3)
+	default: // 사업자/법인등록번호는 공개 정보
+	}
+	return string(r)
+}
+
+func randomDigits(n int) []int {
+	d := make([]int, n)
+	for i := range d {
+		d[i] = seededRand.Intn(10)
+	}
+	return d
+}
+
+func joinDigits(d []int) string {
+	b := make([]byte, len(d))
+	for i, v := range d {
+		b[i] = byte('0' + v)
+	}
+	return string(b)
+}
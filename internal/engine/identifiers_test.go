@@ -0,0 +1,115 @@
+package engine_test
+
+import (
+	"db-pump/internal/engine"
+	"db-pump/internal/schema"
+	"strings"
+	"testing"
+	"time"
+)
+
+func digitsOf(s string) []int {
+	var d []int
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			d = append(d, int(c-'0'))
+		}
+	}
+	return d
+}
+
+func TestIdentifiers_CheckDigits(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		// 주민등록번호: 가중치 2..9,2..5, (11 - 합 % 11) % 10
+		rrn := engine.GenerateRRN(time.Date(2001, 3, 15, 0, 0, 0, 0, time.UTC), false)
+		d := digitsOf(rrn)
+		sum := 0
+		for j, w := range []int{2, 3, 4, 5, 6, 7, 8, 9, 2, 3, 4, 5} {
+			sum += d[j] * w
+		}
+		if !strings.HasPrefix(rrn, "010315-4") || (11-sum%11)%10 != d[12] {
+			t.Fatalf("invalid 주민등록번호 %s", rrn)
+		}
+
+		// 사업자등록번호
+		biz := engine.GenerateBizNo()
+		d = digitsOf(biz)
+		sum = 0
+		for j, w := range []int{1, 3, 7, 1, 3, 7, 1, 3, 5} {
+			sum += d[j] * w
+		}
+		sum += d[8] * 5 / 10
+		if len(biz) != 12 || (10-sum%10)%10 != d[9] {
+			t.Fatalf("invalid 사업자등록번호 %s", biz)
+		}
+
+		// 법인등록번호
+		corp := engine.GenerateCorpNo()
+		d = digitsOf(corp)
+		sum = 0
+		for j := 0; j < 12; j++ {
+			sum += d[j] * (1 + j%2)
+		}
+		if (10-sum%10)%10 != d[12] {
+			t.Fatalf("invalid 법인등록번호 %s", corp)
+		}
+
+		// 카드번호 (Luhn)
+		card := engine.GenerateCardNo()
+		d = digitsOf(card)
+		sum = 0
+		for j := len(d) - 1; j >= 0; j-- {
+			v := d[j]
+			if (len(d)-1-j)%2 == 1 {
+				if v *= 2; v > 9 {
+					v -= 9
+				}
+			}
+			sum += v
+		}
+		if len(d) != 16 || sum%10 != 0 {
+			t.Fatalf("card number %s fails the Luhn check", card)
+		}
+	}
+}
+
+func TestGenerateValue_IdentifierColumns(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+
+	rrn := &schema.Column{Name: "rrn", DataType: "char", Length: 13, Meaning: schema.AnalyzeMeaning("rrn", "")}
+	if v := engine.GenerateValue(rrn, "member").(string); len(v) != 13 || strings.Contains(v, "-") {
+		t.Errorf("CHAR(13) 주민등록번호 should drop the hyphen, got %q", v)
+	}
+
+	engine.Settings.Identifiers.Mask = true
+	rrn.Length = 14
+	if v := engine.GenerateValue(rrn, "member").(string); !strings.HasSuffix(v, "******") {
+		t.Errorf("masked 주민등록번호 %q", v)
+	}
+	card := &schema.Column{Name: "card_no", DataType: "varchar", Length: 20, Meaning: schema.AnalyzeMeaning("card_no", "")}
+	if v := engine.GenerateValue(card, "payment").(string); !strings.Contains(v, "**-****-") {
+		t.Errorf("masked card number %q", v)
+	}
+}
+
+func TestGenerateValue_IdentifierFitsColumn(t *testing.T) {
+	// 하이픈을 빼도 긴 경우 컬럼 길이에 맞춤
+	card := &schema.Column{Name: "card_no", DataType: "varchar", Length: 12, Meaning: schema.AnalyzeMeaning("card_no", "")}
+	for i := 0; i < 50; i++ {
+		if v := engine.GenerateValue(card, "payment").(string); len(v) > 12 {
+			t.Fatalf("VARCHAR(12) card number %q", v)
+		}
+	}
+
+	// 계좌번호는 number/no/bank가 있어야 함: 단독 account는 로그인 계정일 수 있음
+	bank := &schema.Column{Name: "account_no", DataType: "varchar", Length: 20, Meaning: schema.AnalyzeMeaning("account_no", "")}
+	if v := engine.GenerateValue(bank, "payout").(string); len(digitsOf(v)) < 10 {
+		t.Errorf("account_no got %q, want an account number", v)
+	}
+	login := &schema.Column{Name: "account", DataType: "varchar", Length: 20, Meaning: schema.AnalyzeMeaning("account", "")}
+	for i := 0; i < 20; i++ {
+		if v := engine.GenerateValue(login, "users").(string); len(digitsOf(v)) >= 10 {
+			t.Fatalf("bare account column got account number %q", v)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"db-pump/internal/schema"
+
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// JSONColumnConfig shapes the documents generated for one JSON column.
+// Example and Schema accept either inline JSON or a path to a .json file.
+// They are plain strings so that viper does not lower-case the keys.
+type JSONColumnConfig struct {
+	Table   string `mapstructure:"table"` // optional; empty matches the column in any table
+	Column  string `mapstructure:"column"`
+	Example string `mapstructure:"example"` // document whose structure is mimicked
+	Schema  string `mapstructure:"schema"`  // JSON Schema (type/properties/items/enum/format/minimum/maximum)
+}
+
+// parsedJSONDocs caches decoded Example/Schema sources by their raw config string.
+var parsedJSONDocs = make(map[string]interface{})
+
+// generateJSON returns a JSON document for col, shaped by Settings.JSON when configured.
+func generateJSON(col *schema.Column, tableName string) string {
+	var doc interface{}
+	if cfg := jsonColumnConfig(col.Name, tableName); cfg != nil {
+		if cfg.Schema != "" {
+			if s, ok := loadJSONDoc(cfg.Schema).(map[string]interface{}); ok {
+				doc = generateFromJSONSchema(s, "")
+			}
+		} else if cfg.Example != "" {
+			if ex := loadJSONDoc(cfg.Example); ex != nil {
+				doc = generateFromExample(ex, "")
+			}
+		}
+	}
+	if doc == nil {
+		doc = defaultJSONObject()
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+func jsonColumnConfig(colName, tableName string) *JSONColumnConfig {
+	for i, c := range Settings.JSON {
+		if strings.EqualFold(c.Column, colName) && (c.Table == "" || strings.EqualFold(c.Table, tableName)) {
+			return &Settings.JSON[i]
+		}
+	}
+	return nil
+}
+
+// loadJSONDoc decodes inline JSON or reads it from a file. Failures are reported once and yield nil.
+func loadJSONDoc(src string) interface{} {
+	if doc, ok := parsedJSONDocs[src]; ok {
+		return doc
+	}
+	raw := []byte(src)
+	if t := strings.TrimSpace(src); !strings.HasPrefix(t, "{") && !strings.HasPrefix(t, "[") {
+		b, err := os.ReadFile(t)
+		if err != nil {
+			fmt.Printf("Warning: cannot read JSON config %s: %v\n", t, err)
+			parsedJSONDocs[src] = nil
+			return nil
+		}
+		raw = b
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		fmt.Printf("Warning: invalid JSON in config (%.40s...): %v\n", src, err)
+		doc = nil
+	}
+	parsedJSONDocs[src] = doc
+	return doc
+}
+
+// generateFromExample produces a document with the same structure as ex.
+// String leaves are generated from the key name, like a column with that name would be.
+func generateFromExample(ex interface{}, key string) interface{} {
+	switch v := ex.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			out[k] = generateFromExample(child, k)
+		}
+		return out
+	case []interface{}:
+		if len(v) == 0 {
+			return []interface{}{}
+		}
+		n := 1 + seededRand.Intn(3)
+		out := make([]interface{}, n)
+		for i := range out {
+			out[i] = generateFromExample(v[0], key)
+		}
+		return out
+	case string:
+		return jsonStringFor(key, "")
+	case float64:
+		// same magnitude as the example value
+		limit := math.Abs(v)*2 + 10
+		if v == math.Trunc(v) {
+			return seededRand.Int63n(int64(limit)) + 1
+		}
+		return gofakeit.Price(0, limit)
+	case bool:
+		return gofakeit.Bool()
+	default:
+		return nil
+	}
+}
+
+// generateFromJSONSchema supports the common subset of JSON Schema.
+func generateFromJSONSchema(s map[string]interface{}, key string) interface{} {
+	if enum, ok := s["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[seededRand.Intn(len(enum))]
+	}
+	typ, _ := s["type"].(string)
+	if types, ok := s["type"].([]interface{}); ok && len(types) > 0 {
+		typ, _ = types[0].(string)
+	}
+
+	switch typ {
+	case "object", "":
+		props, _ := s["properties"].(map[string]interface{})
+		if typ == "" && props == nil {
+			return nil
+		}
+		out := make(map[string]interface{}, len(props))
+		for k, p := range props {
+			if ps, ok := p.(map[string]interface{}); ok {
+				out[k] = generateFromJSONSchema(ps, k)
+			}
+		}
+		return out
+	case "array":
+		items, _ := s["items"].(map[string]interface{})
+		lo := max(jsonNumber(s, "minItems", 1), 0)
+		hi := max(jsonNumber(s, "maxItems", 3), lo)
+		n := int(lo) + seededRand.Intn(int(hi-lo)+1)
+		out := make([]interface{}, n)
+		for i := range out {
+			if items != nil {
+				out[i] = generateFromJSONSchema(items, key)
+			}
+		}
+		return out
+	case "integer":
+		return randInt64(int64(jsonNumber(s, "minimum", 1)), int64(jsonNumber(s, "maximum", 1000)))
+	case "number":
+		return gofakeit.Price(jsonNumber(s, "minimum", 0), jsonNumber(s, "maximum", 1000))
+	case "boolean":
+		return gofakeit.Bool()
+	case "string":
+		format, _ := s["format"].(string)
+		return jsonStringFor(key, format)
+	}
+	return nil
+}
+
+func jsonNumber(s map[string]interface{}, name string, def float64) float64 {
+	if v, ok := s[name].(float64); ok {
+		return v
+	}
+	return def
+}
+
+// jsonStringFor generates a string leaf, reusing column semantics of the key name.
+func jsonStringFor(key, format string) string {
+	switch format {
+	case "email":
+		return gofakeit.Email()
+	case "uuid":
+		return gofakeit.UUID()
+	case "date":
+		return gofakeit.DateRange(time.Now().AddDate(-1, 0, 0), time.Now()).Format("2006-01-02")
+	case "date-time":
+		return gofakeit.DateRange(time.Now().AddDate(-1, 0, 0), time.Now()).Format(time.RFC3339)
+	case "ipv4":
+		return gofakeit.IPv4Address()
+	case "uri":
+		return gofakeit.URL()
+	}
+	leaf := &schema.Column{Name: key, DataType: "varchar", Length: 100, Meaning: schema.AnalyzeMeaning(key, "")}
+	return fmt.Sprintf("%v", GenerateValue(leaf, ""))
+}
+
+// defaultJSONObject is used when no example or schema is configured.
+func defaultJSONObject() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         seededRand.Intn(100000) + 1,
+		"name":       rowPersona().fullName(),
+		"email":      rowPersona().email(),
+		"active":     gofakeit.Bool(),
+		"tags":       strings.Fields(generateEnglishText(1 + seededRand.Intn(3))),
+		"created_at": gofakeit.DateRange(time.Now().AddDate(-1, 0, 0), time.Now()).Format(time.RFC3339),
+	}
+}
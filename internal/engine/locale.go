@@ -0,0 +1,230 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// Locale is a language pack for semantic columns (names, addresses, phones, postal codes, text).
+// Dictionaries left nil fall back to gofakeit, which makes gofakeit the English backend.
+// Files configured under settings.dictionaries take precedence over the pack's own lists.
+type Locale struct {
+	Code        string
+	Country     string
+	FamilyFirst bool   // 성 + 이름 순서 (ko, ja, zh)
+	NameSep     string // separator between family and given name
+	WordSep     string // separator between words of generated text
+
+	LastNames        *Dictionary
+	FirstNames       *Dictionary
+	FirstNamesMale   *Dictionary
+	FirstNamesFemale *Dictionary
+	Cities           *Dictionary
+	Districts        *Dictionary
+	Streets          *Dictionary
+	Words            *Dictionary
+	Regions          []*Region         // 시/도 → 시/군/구 → 도로명 hierarchy; keeps a row's address columns consistent
+	Readings         map[string]string // romanization of names (ja) or characters (zh) for emails and usernames
+
+	Genders      [2]string // male, female
+	EmailDomains []string
+
+	PhoneFormat   string // '#' is replaced by a digit
+	PostalFormat  string
+	AddressFormat string // {city} {district} {street}; each {n} is a separate number
+	DetailFormat  string // second address line (floor, unit)
+}
+
+// locales holds the built-in packs, keyed by settings.language.
+var locales = map[string]*Locale{
+	"ko": {
+		Code: "ko", Country: "대한민국", FamilyFirst: true, WordSep: " ",
+		LastNames:      embeddedDictionary("data/ko/last_names.csv"),
+		FirstNamesMale: embeddedDictionary("data/ko/first_names_male.txt"), FirstNamesFemale: embeddedDictionary("data/ko/first_names_female.txt"),
+		Cities: NewDictionary(Cities), Districts: NewDictionary(Districts), Streets: NewDictionary(Streets),
+		Regions: loadRegions("data/ko/regions.csv"),
+		Genders: [2]string{"남성", "여성"}, EmailDomains: []string{"naver.com", "gmail.com", "daum.net", "kakao.com", "hanmail.net"},
+		PhoneFormat: "010-####-####", PostalFormat: "#####",
+		AddressFormat: "{city} {district} {street} {n}", DetailFormat: "{n}층 {n}호",
+	},
+	"en": {
+		Code: "en", Country: "United States", NameSep: " ", WordSep: " ",
+		FirstNamesMale: embeddedDictionary("data/en/first_names_male.txt"), FirstNamesFemale: embeddedDictionary("data/en/first_names_female.txt"),
+		Cities: embeddedDictionary("data/en/cities.txt"), Districts: embeddedDictionary("data/en/districts.txt"), Streets: embeddedDictionary("data/en/streets.txt"),
+		Genders: [2]string{"male", "female"}, EmailDomains: []string{"gmail.com", "yahoo.com", "outlook.com", "hotmail.com", "icloud.com"},
+		PhoneFormat: "(###) ###-####", PostalFormat: "#####",
+		AddressFormat: "{n} {street}, {city}, {district}", DetailFormat: "Apt. {n}",
+	},
+	"ja": {
+		Code: "ja", Country: "日本", FamilyFirst: true, NameSep: " ",
+		LastNames:      embeddedDictionary("data/ja/last_names.txt"),
+		FirstNamesMale: embeddedDictionary("data/ja/first_names_male.txt"), FirstNamesFemale: embeddedDictionary("data/ja/first_names_female.txt"),
+		Cities: embeddedDictionary("data/ja/cities.txt"), Districts: embeddedDictionary("data/ja/districts.txt"),
+		Streets: embeddedDictionary("data/ja/streets.txt"), Words: embeddedDictionary("data/ja/words.txt"),
+		Readings: embeddedReadings("data/ja/romaji.csv"),
+		Genders:  [2]string{"男性", "女性"}, EmailDomains: []string{"gmail.com", "yahoo.co.jp", "docomo.ne.jp", "icloud.com"},
+		PhoneFormat: "090-####-####", PostalFormat: "###-####",
+		AddressFormat: "{city}{district}{street}{n}-{n}-{n}", DetailFormat: "{n}階{n}号室",
+	},
+	"zh": {
+		Code: "zh", Country: "中国", FamilyFirst: true,
+		LastNames:      embeddedDictionary("data/zh/last_names.csv"),
+		FirstNamesMale: embeddedDictionary("data/zh/first_names_male.txt"), FirstNamesFemale: embeddedDictionary("data/zh/first_names_female.txt"),
+		Cities: embeddedDictionary("data/zh/cities.txt"), Districts: embeddedDictionary("data/zh/districts.txt"),
+		Streets: embeddedDictionary("data/zh/streets.txt"), Words: embeddedDictionary("data/zh/words.txt"),
+		Readings: embeddedReadings("data/zh/pinyin.csv"),
+		Genders:  [2]string{"男", "女"}, EmailDomains: []string{"qq.com", "163.com", "126.com", "sina.com"},
+		PhoneFormat: "13#-####-####", PostalFormat: "######",
+		AddressFormat: "{city}{district}{street}{n}号", DetailFormat: "{n}层{n}室",
+	},
+}
+
+func init() {
+	// 한국어/영어 어휘는 영-한 사전에서 가져온다
+	en, ko := &Dictionary{}, &Dictionary{}
+	for k, v := range EngToKorMap {
+		en.Values = append(en.Values, k)
+		ko.Values = append(ko.Values, v)
+	}
+	locales["en"].Words, locales["ko"].Words = en, ko
+
+	// 성별 구분 없는 이름 목록은 남/여 목록을 합친 것 (영어는 gofakeit 목록 유지)
+	for _, code := range []string{"ko", "ja", "zh"} {
+		l := locales[code]
+		l.FirstNames = mergeDictionaries(l.FirstNamesMale, l.FirstNamesFemale)
+	}
+}
+
+// LookupLocale returns the pack for a settings.language code ("ko", "en", "ja", "zh").
+func LookupLocale(code string) (*Locale, bool) {
+	l, ok := locales[strings.ToLower(strings.TrimSpace(code))]
+	return l, ok
+}
+
+// activeLocale is the pack selected by settings.language, Korean by default.
+func activeLocale() *Locale {
+	if l, ok := LookupLocale(Settings.Language); ok {
+		return l
+	}
+	return locales["ko"]
+}
+
+// pick draws from the configured dictionary file, the pack's own list, or gofakeit, in that order.
+func pick(name string, own *Dictionary, fallback func() string) string {
+	if d, ok := loadedDictionaries[name]; ok {
+		return d.Pick()
+	}
+	if own.Len() == 0 {
+		return fallback()
+	}
+	return own.Pick()
+}
+
+func (l *Locale) LastName() string  { return pick("last_names", l.LastNames, gofakeit.LastName) }
+func (l *Locale) FirstName() string { return pick("first_names", l.FirstNames, gofakeit.FirstName) }
+func (l *Locale) Word() string      { return pick("words", l.Words, gofakeit.Word) }
+
+// GivenName returns a male or female given name. A configured first_names file
+// without gendered counterparts is used for both.
+func (l *Locale) GivenName(male bool) string {
+	name, own := "first_names_female", l.FirstNamesFemale
+	if male {
+		name, own = "first_names_male", l.FirstNamesMale
+	}
+	if _, ok := loadedDictionaries[name]; !ok {
+		if _, ok := loadedDictionaries["first_names"]; ok {
+			return l.FirstName()
+		}
+	}
+	return pick(name, own, l.FirstName)
+}
+
+func (l *Locale) City() string {
+	if a := l.rowAddress(); a != nil {
+		return a.city()
+	}
+	return pick("cities", l.Cities, gofakeit.City)
+}
+
+func (l *Locale) District() string {
+	if a := l.rowAddress(); a != nil {
+		return a.district()
+	}
+	return pick("districts", l.Districts, gofakeit.State)
+}
+
+func (l *Locale) Street() string {
+	if a := l.rowAddress(); a != nil {
+		return a.road
+	}
+	return pick("streets", l.Streets, gofakeit.StreetName)
+}
+
+// Name returns a full name in the locale's order, e.g. "김민준", "Jane Doe", "佐藤 翔太".
+func (l *Locale) Name() string {
+	return l.JoinName(l.LastName(), l.FirstName())
+}
+
+// JoinName puts a family and given name in the locale's order.
+func (l *Locale) JoinName(last, first string) string {
+	if l.FamilyFirst {
+		return last + l.NameSep + first
+	}
+	return first + l.NameSep + last
+}
+
+// Address returns a one-line street address, e.g. "서울특별시 강남구 테헤란로 123".
+func (l *Locale) Address() string {
+	if a := l.rowAddress(); a != nil {
+		return strings.NewReplacer("{city}", a.region.Province, "{district}", a.region.District,
+			"{street}", a.road, "{n}", strconv.Itoa(a.building)).Replace(l.AddressFormat)
+	}
+	s := strings.NewReplacer("{city}", l.City(), "{district}", l.District(), "{street}", l.Street()).Replace(l.AddressFormat)
+	return fillNumbers(s)
+}
+
+// AddressDetail returns a second address line such as "3층 5호" or "Apt. 12".
+func (l *Locale) AddressDetail() string {
+	return fillNumbers(l.DetailFormat)
+}
+
+func (l *Locale) Phone() string { return fillDigits(l.PhoneFormat) }
+
+// PostalCode returns a code inside the row address's 우편번호 range when the locale has regions.
+func (l *Locale) PostalCode() string {
+	if a := l.rowAddress(); a != nil {
+		return a.zip
+	}
+	return fillDigits(l.PostalFormat)
+}
+
+// Text returns n words of locale vocabulary.
+func (l *Locale) Text(n int) string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = l.Word()
+	}
+	return strings.Join(words, l.WordSep)
+}
+
+// fillNumbers replaces each {n} with its own small number (house, floor, unit).
+func fillNumbers(s string) string {
+	for strings.Contains(s, "{n}") {
+		s = strings.Replace(s, "{n}", fmt.Sprintf("%d", seededRand.Intn(99)+1), 1)
+	}
+	return s
+}
+
+// fillDigits replaces each '#' with a random digit.
+func fillDigits(format string) string {
+	b := []byte(format)
+	for i, c := range b {
+		if c == '#' {
+			b[i] = byte('0' + seededRand.Intn(10))
+		}
+	}
+	return string(b)
+}
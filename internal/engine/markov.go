@@ -0,0 +1,320 @@
+package engine
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"db-pump/internal/schema"
+)
+
+// TextConfig selects the Markov models used for free-text columns
+// (title, description, comment, ...). Models are trained with `db-pump train`.
+type TextConfig struct {
+	Model   string             `mapstructure:"model"` // default model; empty = built-in corpus of settings.language
+	Columns []TextColumnConfig `mapstructure:"columns"`
+}
+
+// TextColumnConfig assigns a model to one column ("table" may be omitted).
+type TextColumnConfig struct {
+	Table  string `mapstructure:"table"`
+	Column string `mapstructure:"column"`
+	Model  string `mapstructure:"model"`
+}
+
+// TextModel is an n-gram (Markov chain) model of sentences. Languages written
+// without spaces (ja, zh) are modelled per character, others per word.
+type TextModel struct {
+	Order  int
+	Chars  bool
+	States map[string]*textState // previous Order tokens joined by stateSep → next tokens
+}
+
+type textState struct {
+	Next   []string // "" ends the sentence
+	Counts []int
+}
+
+const stateSep = "\x1f"
+
+// sentenceEnds are the runes after which a corpus line is split into sentences.
+const sentenceEnds = ".!?。！？"
+
+// TrainTextModel builds a model from sample texts (corpus lines or column values).
+func TrainTextModel(samples []string, order int, chars bool) *TextModel {
+	if order < 1 {
+		order = 1
+	}
+	counts := make(map[string]map[string]int)
+	for _, sample := range samples {
+		for _, sentence := range splitSentences(sample) {
+			state := make([]string, order)
+			for _, tok := range append(tokenize(sentence, chars), "") {
+				key := strings.Join(state, stateSep)
+				if counts[key] == nil {
+					counts[key] = make(map[string]int)
+				}
+				counts[key][tok]++
+				state = append(state[1:], tok)
+			}
+		}
+	}
+
+	m := &TextModel{Order: order, Chars: chars, States: make(map[string]*textState, len(counts))}
+	for key, next := range counts {
+		s := &textState{}
+		for tok := range next {
+			s.Next = append(s.Next, tok)
+		}
+		sort.Strings(s.Next)
+		for _, tok := range s.Next {
+			s.Counts = append(s.Counts, next[tok])
+		}
+		m.States[key] = s
+	}
+	return m
+}
+
+func splitSentences(text string) []string {
+	var out []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue // comment line of a corpus file
+		}
+		start := 0
+		runes := []rune(line)
+		for i, r := range runes {
+			if strings.ContainsRune(sentenceEnds, r) && (i+1 == len(runes) || !strings.ContainsRune(sentenceEnds, runes[i+1])) {
+				out = appendSentence(out, string(runes[start:i+1]))
+				start = i + 1
+			}
+		}
+		out = appendSentence(out, string(runes[start:]))
+	}
+	return out
+}
+
+func appendSentence(out []string, s string) []string {
+	if s = strings.TrimSpace(s); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
+
+func tokenize(sentence string, chars bool) []string {
+	if !chars {
+		return strings.Fields(sentence)
+	}
+	var toks []string
+	for _, r := range sentence {
+		if !unicode.IsSpace(r) {
+			toks = append(toks, string(r))
+		}
+	}
+	return toks
+}
+
+func (m *TextModel) join(toks []string) string {
+	if m.Chars {
+		return strings.Join(toks, "")
+	}
+	return strings.Join(toks, " ")
+}
+
+// Sentence generates one sentence of at most maxTokens tokens (0 = until the model ends it).
+func (m *TextModel) Sentence(maxTokens int) string {
+	state := make([]string, m.Order)
+	var toks []string
+	for len(toks) < 200 && (maxTokens <= 0 || len(toks) < maxTokens) {
+		s := m.States[strings.Join(state, stateSep)]
+		if s == nil {
+			break
+		}
+		tok := s.pick()
+		if tok == "" {
+			break
+		}
+		toks = append(toks, tok)
+		state = append(state[1:], tok)
+	}
+	return m.join(toks)
+}
+
+func (s *textState) pick() string {
+	total := 0
+	for _, c := range s.Counts {
+		total += c
+	}
+	r := seededRand.Intn(total)
+	for i, c := range s.Counts {
+		if r < c {
+			return s.Next[i]
+		}
+		r -= c
+	}
+	return s.Next[len(s.Next)-1]
+}
+
+// Text generates whole sentences up to about n characters, never exceeding limit
+// (0 = no limit). A single sentence longer than the limit is cut at a word boundary.
+func (m *TextModel) Text(n, limit int) string {
+	if limit > 0 && (n <= 0 || n > limit) {
+		n = limit
+	}
+	sep := " "
+	if m.Chars {
+		sep = ""
+	}
+	var out string
+	for tries := 0; tries < 20; tries++ {
+		s := m.Sentence(0)
+		if s == "" {
+			break
+		}
+		next := s
+		if out != "" {
+			next = out + sep + s
+		}
+		if len([]rune(next)) > n {
+			if out != "" {
+				break
+			}
+			if tries < 10 {
+				continue // look for a sentence that fits before cutting one
+			}
+			out = cutText(s, n, m.Chars)
+			break
+		}
+		out = next
+		if len([]rune(out)) >= n*2/3 {
+			break
+		}
+	}
+	return out
+}
+
+// cutText shortens s to n runes, at the last space when words are the unit.
+func cutText(s string, n int, chars bool) string {
+	cut := truncate(s, n)
+	if chars || cut == s {
+		return cut
+	}
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		return cut[:i]
+	}
+	return cut
+}
+
+// SaveTextModel writes a model as gzip-compressed gob.
+func SaveTextModel(m *TextModel, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zw := gzip.NewWriter(f)
+	if err := gob.NewEncoder(zw).Encode(m); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// LoadTextModel reads a model written by SaveTextModel.
+func LoadTextModel(path string) (*TextModel, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: not a text model: %w", path, err)
+	}
+	m := &TextModel{}
+	if err := gob.NewDecoder(zr).Decode(m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// textModels holds the models configured under settings.text, keyed by file path.
+var textModels = make(map[string]*TextModel)
+
+// LoadTextModels reads every model file referenced by settings.text.
+func LoadTextModels(cfg TextConfig) error {
+	loaded := make(map[string]*TextModel)
+	paths := []string{cfg.Model}
+	for _, c := range cfg.Columns {
+		paths = append(paths, c.Model)
+	}
+	for _, p := range paths {
+		if _, ok := loaded[p]; ok || p == "" {
+			continue
+		}
+		m, err := LoadTextModel(p)
+		if err != nil {
+			return err
+		}
+		loaded[p] = m
+	}
+	textModels = loaded
+	return nil
+}
+
+// builtinTextModels caches the models trained from data/<lang>/corpus.txt.
+var builtinTextModels = make(map[string]*TextModel)
+
+// textModelFor returns the column's model, the configured default, or the built-in
+// model of the active language.
+func textModelFor(col *schema.Column, tableName string) *TextModel {
+	if col != nil {
+		for _, c := range Settings.Text.Columns {
+			if strings.EqualFold(c.Column, col.Name) && (c.Table == "" || strings.EqualFold(c.Table, tableName)) {
+				if m := textModels[c.Model]; m != nil {
+					return m
+				}
+			}
+		}
+	}
+	if m := textModels[Settings.Text.Model]; m != nil {
+		return m
+	}
+
+	loc := activeLocale()
+	if m, ok := builtinTextModels[loc.Code]; ok {
+		return m
+	}
+	data, err := embeddedData.ReadFile("data/" + loc.Code + "/corpus.txt")
+	if err != nil {
+		panic(err)
+	}
+	chars := loc.WordSep == ""
+	order := 2 // two words / three characters of context keep sentences grammatical
+	if chars {
+		order = 3
+	}
+	m := TrainTextModel([]string{string(data)}, order, chars)
+	builtinTextModels[loc.Code] = m
+	return m
+}
+
+// generateText returns whole sentences of about n characters for a free-text column.
+func generateText(col *schema.Column, tableName string, n int) string {
+	m := textModelFor(col, tableName)
+	return m.Text(n, col.Length)
+}
+
+// generateTitle returns the opening words of a generated sentence.
+func generateTitle(col *schema.Column, tableName string) string {
+	m := textModelFor(col, tableName)
+	words := 3 + seededRand.Intn(3)
+	if m.Chars {
+		words = 6 + seededRand.Intn(6)
+	}
+	title := strings.TrimRight(m.Sentence(words), ".,!?。、，！？")
+	return cutText(title, col.Length, m.Chars)
+}
@@ -0,0 +1,55 @@
+package engine_test
+
+import (
+	"db-pump/internal/engine"
+	"db-pump/internal/schema"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTextModel_TrainSaveLoad(t *testing.T) {
+	corpus := "# 주석은 학습하지 않는다.\nThe red car is fast. The blue car is slow.\nA red bike is fast!"
+	m := engine.TrainTextModel([]string{corpus}, 1, false)
+
+	vocab := map[string]bool{}
+	for _, w := range strings.Fields("The red car is fast. blue slow. A bike fast!") {
+		vocab[w] = true
+	}
+	for i := 0; i < 50; i++ {
+		for _, w := range strings.Fields(m.Sentence(0)) {
+			if !vocab[w] {
+				t.Fatalf("word %q is not in the corpus", w)
+			}
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "cars.model")
+	if err := engine.SaveTextModel(m, path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := engine.LoadTextModel(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Order != 1 || len(loaded.States) != len(m.States) {
+		t.Errorf("model did not round-trip: order %d, %d states", loaded.Order, len(loaded.States))
+	}
+	if got := loaded.Text(0, 12); len([]rune(got)) > 12 {
+		t.Errorf("Text(0, 12) = %q exceeds the limit", got)
+	}
+}
+
+func TestGenerateValue_DescriptionUsesTextModel(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+
+	for _, lang := range []string{"ko", "en", "ja", "zh"} {
+		engine.Settings.Language = lang
+		col := &schema.Column{Name: "description", DataType: "varchar", Meaning: "description", Length: 255}
+		v := engine.GenerateValue(col, "product").(string)
+		// 내장 말뭉치로 학습한 문장: 문장부호로 끝나야 한다
+		if v == "" || len([]rune(v)) > 255 || !strings.ContainsAny(v[len(v)-3:], ".!?。！？") {
+			t.Errorf("%s: description %q is not sentence-like text", lang, v)
+		}
+	}
+}
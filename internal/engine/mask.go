@@ -0,0 +1,605 @@
+package engine
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"path"
+	"strconv"
+	"strings"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/schema"
+
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// MaskConfig controls `db-pump mask`: which columns are anonymized and how.
+// PII columns (names, contacts, addresses, identifiers, credentials) are detected
+// from column meanings and replaced with fake values unless a rule says otherwise.
+type MaskConfig struct {
+	Salt  string           `mapstructure:"salt"` // keys hashes and replacements; the same salt gives the same output
+	Rules []MaskRuleConfig `mapstructure:"rules"`
+}
+
+// ValidateMaskSalt reports an empty settings.mask.salt. Hashes and fake values are keyed
+// with it; without a secret, low-entropy values (phone numbers, 주민등록번호, card
+// numbers) can be recovered by masking every candidate with db-pump and comparing.
+func ValidateMaskSalt(c MaskConfig) error {
+	if strings.TrimSpace(c.Salt) == "" {
+		return fmt.Errorf("settings.mask.salt is empty: set a secret salt (or pass --salt) so masked values can't be reversed")
+	}
+	return nil
+}
+
+// MaskRuleConfig sets the action of matching columns. Table and column accept
+// globs ("*_email"); "table" may be omitted.
+type MaskRuleConfig struct {
+	Table  string `mapstructure:"table"`
+	Column string `mapstructure:"column"`
+	Action string `mapstructure:"action"`
+}
+
+// Mask actions.
+const (
+	MaskFake    = "fake"    // consistent fake value of the same kind (name → name, email → email)
+	MaskHash    = "hash"    // format-preserving keyed hash: digits stay digits, letters letters
+	MaskNull    = "null"    // NULL
+	MaskShuffle = "shuffle" // values of the column permuted across rows
+	MaskKeep    = "keep"    // copied as is
+)
+
+// Masker anonymizes rows. A value is always mapped to the same output, so an FK
+// column, which is masked like the column it references, keeps pointing at its parent.
+type Masker struct {
+	tables  map[string]*schema.Table
+	actions map[string]string                 // "table.column" → action, after fallbacks
+	keys    map[string]map[string]interface{} // "table.column" of a key column → original → masked
+	used    map[string]map[string]bool        // masked values per key column
+	warned  map[string]bool
+}
+
+// NewMasker prepares masking for the given schema (every table, so FKs into tables
+// that are not copied still resolve).
+func NewMasker(all []*schema.Table) *Masker {
+	m := &Masker{
+		tables:  make(map[string]*schema.Table),
+		actions: make(map[string]string),
+		keys:    make(map[string]map[string]interface{}),
+		used:    make(map[string]map[string]bool),
+		warned:  make(map[string]bool),
+	}
+	for _, t := range all {
+		m.tables[strings.ToLower(t.Name)] = t
+	}
+	return m
+}
+
+// owner returns the column whose values col holds: the referenced column for an FK
+// column (following chains), col itself otherwise.
+func (m *Masker) owner(t *schema.Table, col *schema.Column) (*schema.Table, *schema.Column) {
+	for depth := 0; depth < 10; depth++ {
+		var next *schema.ForeignKey
+		for _, fk := range t.ForeignKeys {
+			if fk.Column == col.Name {
+				next = fk
+				break
+			}
+		}
+		if next == nil {
+			return t, col
+		}
+		pt := m.tables[strings.ToLower(next.RefTable)]
+		if pt == nil {
+			return t, col
+		}
+		var pc *schema.Column
+		for _, c := range pt.Columns {
+			if strings.EqualFold(c.Name, next.RefColumn) {
+				pc = c
+			}
+		}
+		if pc == nil {
+			return t, col
+		}
+		t, col = pt, pc
+	}
+	return t, col
+}
+
+// isKey reports whether other rows may refer to or collide on the column's values.
+func (m *Masker) isKey(t *schema.Table, col *schema.Column) bool {
+	if col.IsPK || col.IsUnique {
+		return true
+	}
+	for _, other := range m.tables {
+		for _, fk := range other.ForeignKeys {
+			if strings.EqualFold(fk.RefTable, t.Name) && strings.EqualFold(fk.RefColumn, col.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Action returns what happens to a column: the first matching settings.mask rule,
+// MaskFake for detected PII, MaskKeep otherwise. FK columns follow the column they
+// reference; actions a column can't take (NULL into NOT NULL, shuffling keys) fall back to MaskFake.
+func (m *Masker) Action(t *schema.Table, col *schema.Column) string {
+	ot, oc := m.owner(t, col)
+	id := ot.Name + "." + oc.Name
+	if a, ok := m.actions[id]; ok {
+		return a
+	}
+
+	a := ""
+	for _, r := range Settings.Mask.Rules {
+		if globMatch(r.Column, oc.Name) && (r.Table == "" || globMatch(r.Table, ot.Name)) {
+			a = strings.ToLower(r.Action)
+			break
+		}
+	}
+	if a == "" {
+		a = MaskKeep
+		if isPersonalColumn(ot, oc) {
+			a = MaskFake
+		}
+	}
+	switch {
+	case a != MaskFake && a != MaskHash && a != MaskNull && a != MaskShuffle && a != MaskKeep:
+		m.warn(id, fmt.Sprintf("unknown action %q for %s, using %s", a, id, MaskFake))
+		a = MaskFake
+	case a == MaskNull && (!oc.IsNullable || m.isKey(ot, oc)):
+		m.warn(id, fmt.Sprintf("%s cannot be NULL, using %s", id, MaskFake))
+		a = MaskFake
+	case a == MaskShuffle && m.isKey(ot, oc):
+		m.warn(id, fmt.Sprintf("%s is a key and cannot be shuffled, using %s", id, MaskFake))
+		a = MaskFake
+	}
+	m.actions[id] = a
+	return a
+}
+
+// isPersonalColumn narrows isPIIColumn for masking: a bare "name" column is only
+// personal in a table of people (customer.name, not category.name).
+func isPersonalColumn(t *schema.Table, col *schema.Column) bool {
+	if !isPIIColumn(col) {
+		return false
+	}
+	colName := strings.ToLower(col.Name)
+	if personColumn(colName, col.Meaning) != "" || identifierKind(col.Meaning) != "" || isPasswordColumn(col.Meaning) {
+		return true
+	}
+	for _, w := range strings.Fields(col.Meaning) {
+		switch w {
+		case "phone", "email", "address", "zipcode", "postal", "ip":
+			return true
+		}
+	}
+	if strings.Contains(colName, "phone") || strings.Contains(colName, "email") || strings.Contains(colName, "addr") {
+		return true
+	}
+	subject := strings.ToLower(t.Name) + " " + colName
+	for _, w := range personTables {
+		if strings.Contains(subject, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// personTables are words in table or column names that mark rows describing people.
+var personTables = []string{"user", "member", "customer", "client", "employee", "staff", "person", "people",
+	"actor", "author", "contact", "account", "student", "patient", "guest", "owner", "manager", "applicant", "full"}
+
+func (m *Masker) warn(id, msg string) {
+	if !m.warned[id] {
+		m.warned[id] = true
+		fmt.Printf("[Mask] Warning: %s\n", msg)
+	}
+}
+
+func globMatch(pattern, name string) bool {
+	ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return err == nil && ok
+}
+
+// Row masks the values of one row in place (cols[i] holds values[i]). Shuffled
+// columns are left to the caller, which needs the whole column.
+func (m *Masker) Row(t *schema.Table, cols []*schema.Column, values []interface{}) {
+	for i, c := range cols {
+		if values[i] == nil {
+			continue
+		}
+		ot, oc := m.owner(t, c)
+		switch m.Action(t, c) {
+		case MaskNull:
+			values[i] = nil
+		case MaskHash:
+			if ot != t || m.isKey(ot, oc) {
+				values[i] = m.hashKey(ot, oc, values[i])
+			} else {
+				values[i] = m.hashValue(oc, values[i], 0)
+			}
+		case MaskFake:
+			if ot != t || m.isKey(ot, oc) {
+				values[i] = m.fakeKey(ot, oc, values[i])
+			} else {
+				values[i] = m.fakeValue(c, t.Name, values[i])
+			}
+		}
+	}
+}
+
+// fakeValue replaces a non-key value, seeded by the column's kind and the value itself:
+// the same email masks to the same fake email in every table, without an FK between them.
+// The columns of a row are therefore masked independently (a masked email doesn't
+// follow the masked name of the same row).
+func (m *Masker) fakeValue(col *schema.Column, tableName string, v interface{}) interface{} {
+	var out interface{}
+	m.withSeed(m.seed(maskKind(col), uniqueKeyOf(v)), func() {
+		beginRow()
+		out = GenerateValue(col, tableName)
+	})
+	return out
+}
+
+// maskKind names what a PII column holds (email, first, rrn, phone...), so that
+// columns holding the same kind of value mask it alike whatever their names.
+func maskKind(col *schema.Column) string {
+	if k := personColumn(strings.ToLower(col.Name), col.Meaning); k != "" {
+		return k
+	}
+	if k := identifierKind(col.Meaning); k != "" {
+		return k
+	}
+	if words := strings.Fields(col.Meaning); len(words) > 0 {
+		return words[len(words)-1] // customer phone → phone
+	}
+	return strings.ToLower(col.Name)
+}
+
+// fakeKey maps a key value to a fake value of the column's kind.
+func (m *Masker) fakeKey(t *schema.Table, col *schema.Column, v interface{}) interface{} {
+	k := uniqueKeyOf(v)
+	return m.mapKey(t, col, v, func(attempt int) interface{} {
+		if attempt >= 20 {
+			return m.hashValue(col, v, attempt) // no free fake value left; hashes widen with the attempt
+		}
+		var out interface{}
+		m.withSeed(m.seed(k, strconv.Itoa(attempt)), func() {
+			beginRow()
+			out = GenerateValue(col, t.Name)
+		})
+		if s, ok := out.(string); ok && attempt >= 5 {
+			out = uniqueString(s, attempt, col.Length)
+		}
+		return out
+	})
+}
+
+// hashKey maps a key value to its format-preserving hash, rehashing on collision.
+func (m *Masker) hashKey(t *schema.Table, col *schema.Column, v interface{}) interface{} {
+	return m.mapKey(t, col, v, func(attempt int) interface{} {
+		return m.hashValue(col, v, attempt)
+	})
+}
+
+// mapKey maps a key value through gen, the same output wherever the value appears and
+// never the same for two different values: gen is retried with the next attempt while
+// its output is taken, since neither fake values nor hashes are injective.
+func (m *Masker) mapKey(t *schema.Table, col *schema.Column, v interface{}, gen func(attempt int) interface{}) interface{} {
+	id := t.Name + "." + col.Name
+	k := uniqueKeyOf(v)
+	if out, ok := m.keys[id][k]; ok {
+		return out
+	}
+	if m.keys[id] == nil {
+		m.keys[id], m.used[id] = make(map[string]interface{}), make(map[string]bool)
+	}
+
+	var out interface{}
+	for attempt := 0; ; attempt++ {
+		out = gen(attempt)
+		if !m.used[id][uniqueKeyOf(out)] {
+			break
+		}
+		if attempt >= 1000 {
+			m.warn(id+"#full", fmt.Sprintf("no unique masked value left for %s (length %d)", id, col.Length))
+			break
+		}
+	}
+	m.keys[id][k] = out
+	m.used[id][uniqueKeyOf(out)] = true
+	return out
+}
+
+// withSeed runs fn with the generators seeded deterministically.
+func (m *Masker) withSeed(seed int64, fn func()) {
+	saved := seededRand
+	seededRand = rand.New(rand.NewSource(seed))
+	gofakeit.Seed(seed)
+	defer func() {
+		seededRand = saved
+		gofakeit.Seed(0)
+	}()
+	fn()
+}
+
+func (m *Masker) seed(parts ...string) int64 {
+	sum := m.mac(strings.Join(parts, "\x1f"), 0)
+	return int64(binary.BigEndian.Uint64(sum[:8]))
+}
+
+func (m *Masker) mac(s string, block int) []byte {
+	h := hmac.New(sha256.New, []byte(Settings.Mask.Salt))
+	h.Write([]byte(s))
+	h.Write([]byte{byte(block >> 8), byte(block)})
+	return h.Sum(nil)
+}
+
+// hashValue applies FormatPreservingHash to strings and numbers; other types
+// (dates, binary) get a fake value instead. Attempts after the first use another key,
+// and from attempt 20 on add characters, so a full format still yields new values.
+func (m *Masker) hashValue(col *schema.Column, v interface{}, attempt int) interface{} {
+	switch t := v.(type) {
+	case string:
+		if kind, _ := profileKind(col); kind == "number" {
+			if n, err := strconv.ParseInt(strings.TrimSpace(t), 10, 64); err == nil {
+				return m.hashInt(n, attempt)
+			}
+		}
+		return m.hashString(t, attempt, col.Length)
+	case int64:
+		return m.hashInt(t, attempt)
+	case int:
+		return m.hashInt(int64(t), attempt)
+	case float64:
+		f, err := strconv.ParseFloat(m.hashString(strconv.FormatFloat(t, 'f', -1, 64), attempt, 0), 64)
+		if err != nil {
+			return t
+		}
+		return f
+	case []byte:
+		if isStringColumn(col) {
+			return m.hashString(string(t), attempt, col.Length)
+		}
+	}
+	var out interface{}
+	m.withSeed(m.seed(uniqueKeyOf(v), strconv.Itoa(attempt)), func() {
+		beginRow()
+		out = GenerateValue(col, "")
+	})
+	return out
+}
+
+func (m *Masker) hashInt(n int64, attempt int) int64 {
+	s := m.hashString(strconv.FormatInt(n, 10), attempt, 0)
+	if len(s) > 1 && (s[0] == '0' || (s[0] == '-' && s[1] == '0')) {
+		s = strings.Replace(s, "0", "1", 1) // keep the number of digits
+	}
+	out, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return n
+	}
+	return out
+}
+
+func (m *Masker) hashString(s string, attempt, length int) string {
+	salt := Settings.Mask.Salt
+	if attempt > 0 {
+		salt += "\x00" + strconv.Itoa(attempt)
+	}
+	h := FormatPreservingHash(salt, s)
+	if attempt >= 20 {
+		// Every value of the format is taken (e.g. one-digit ids): widen with hashed digits
+		h += FormatPreservingHash(salt, strings.Repeat("0", 1+attempt/20))
+		if length > 0 && len([]rune(h)) > length {
+			h = uniqueString(truncate(h, length), attempt, length)
+		}
+	}
+	return h
+}
+
+// FormatPreservingHash replaces every digit with a digit, every letter with a letter
+// of the same case and every Hangul syllable with a syllable, keyed by salt; other
+// characters (-, @, ., spaces) stay, so "010-1234-5678" still looks like a phone number.
+func FormatPreservingHash(salt, s string) string {
+	h := hmac.New(sha256.New, []byte(salt))
+	h.Write([]byte(s))
+	seed := h.Sum(nil)
+	stream := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(seed[:8]))))
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune('0' + rune(stream.Intn(10)))
+		case r >= 'a' && r <= 'z':
+			b.WriteRune('a' + rune(stream.Intn(26)))
+		case r >= 'A' && r <= 'Z':
+			b.WriteRune('A' + rune(stream.Intn(26)))
+		case r >= 0xAC00 && r <= 0xD7A3:
+			b.WriteRune(0xAC00 + rune(stream.Intn(11172)))
+		default: // other scripts and separators are kept
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Copy reads the rows of tables from src (every row Scan returns) and writes them to
+// dst in the given (dependency) order. m masks every row (nil = copy as is).
+// Tables with shuffled columns are read into memory.
+func Copy(src RowSource, dst *sql.DB, dstD dialect.Dialect, tables []*schema.Table, m *Masker, onTable func(table string)) ([]schema.PumpResult, error) {
+
+	var results []schema.PumpResult
+	for _, t := range tables {
+		read, written, err := copyTable(src, dst, dstD, t, m)
+		if err != nil {
+			return results, fmt.Errorf("copy %s: %w", t.Name, err)
+		}
+		r := schema.PumpResult{TableName: t.Name, Target: read, Actual: written, Status: "OK"}
+		if written < read {
+			r.Status = "MISSING DATA"
+			r.ErrorMsg = fmt.Sprintf("Only inserted %d out of %d. Check logs for details.", written, read)
+		}
+		results = append(results, r)
+		if onTable != nil {
+			onTable(t.Name)
+		}
+	}
+	return results, nil
+}
+
+func copyTable(src RowSource, dst *sql.DB, dstD dialect.Dialect, t *schema.Table, m *Masker) (int, int, error) {
+	var cols []*schema.Column
+	var names, types []string
+	hasIdentity := false
+	for _, c := range t.Columns {
+		if c.IsGenerated {
+			continue // computed by the target
+		}
+		cols = append(cols, c)
+		names = append(names, c.Name)
+		types = append(types, c.DataType)
+		hasIdentity = hasIdentity || c.IsAutoInc
+	}
+	if len(cols) == 0 {
+		return 0, 0, nil
+	}
+
+	var shuffled []int
+	if m != nil {
+		for i, c := range cols {
+			if m.Action(t, c) == MaskShuffle {
+				shuffled = append(shuffled, i)
+			}
+		}
+	}
+
+	tx, err := dst.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := dstD.BeforeTable(tx, t.Name, hasIdentity); err != nil {
+		if errors.Is(err, dialect.ErrExplicitIdentity) {
+			// Source ids are copied as is (FKs point at them); without them every row would fail
+			tx.Rollback()
+			return 0, 0, err
+		}
+		fmt.Printf("Warning: BeforeTable hook failed for %s: %v\n", t.Name, err)
+	}
+	query := dstD.InsertQuery(t.Name, names, types)
+
+	// On PostgreSQL a failed statement aborts the whole transaction: wrap each row in
+	// a savepoint so a rejected row is skipped like on the other databases.
+	_, savepoint := dstD.(*dialect.PostgresDialect)
+
+	read, written := 0, 0
+	insert := func(values []interface{}) {
+		if savepoint {
+			if _, err := tx.Exec("SAVEPOINT copy_row"); err != nil {
+				return
+			}
+		}
+		_, err := tx.Exec(query, values...)
+		if err == nil {
+			written++
+		} else if read-written <= 3 {
+			fmt.Printf("[DEBUG] Table %s row %d: %v\n", t.Name, read, err)
+		}
+		if savepoint {
+			if err != nil {
+				tx.Exec("ROLLBACK TO SAVEPOINT copy_row")
+			} else {
+				tx.Exec("RELEASE SAVEPOINT copy_row")
+			}
+		}
+	}
+
+	var buffered [][]interface{}
+	err = src.Scan(t, "", func(raw []interface{}) {
+		read++
+		if m != nil {
+			m.Row(t, cols, raw)
+		}
+		if len(shuffled) > 0 {
+			buffered = append(buffered, raw)
+			return
+		}
+		insert(raw)
+	})
+	if err != nil {
+		tx.Rollback()
+		return read, written, err
+	}
+
+	if len(buffered) > 0 {
+		for _, i := range shuffled {
+			r := rand.New(rand.NewSource(m.seed(t.Name, cols[i].Name)))
+			r.Shuffle(len(buffered), func(a, b int) {
+				buffered[a][i], buffered[b][i] = buffered[b][i], buffered[a][i]
+			})
+		}
+		read, written = 0, 0
+		for _, values := range buffered {
+			read++
+			insert(values)
+		}
+	}
+
+	if err := dstD.AfterTable(tx, t.Name, hasIdentity); err != nil {
+		fmt.Printf("Warning: AfterTable hook failed for %s: %v\n", t.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return read, written, err
+	}
+	if hasIdentity {
+		reseedIdentity(dst, dstD, t, cols)
+	}
+	return read, written, nil
+}
+
+// reseedIdentity moves the identity sequences of t past the copied ids: explicit ids
+// don't advance them, so later INSERTs (e.g. a pump) would collide on the PK.
+func reseedIdentity(dst *sql.DB, dstD dialect.Dialect, t *schema.Table, cols []*schema.Column) {
+	switch d := dstD.(type) {
+	case *dialect.PostgresDialect:
+		for _, c := range cols {
+			if !c.IsAutoInc {
+				continue
+			}
+			// pg_get_serial_sequence parses the table name (quoted identifier) but takes the column name as is
+			query := fmt.Sprintf("SELECT setval(pg_get_serial_sequence($1, $2), COALESCE(MAX(%s), 0) + 1, false) FROM %s",
+				d.QuoteIdent(c.Name), d.QuoteIdent(t.Name))
+			if _, err := dst.Exec(query, d.QuoteIdent(t.Name), c.Name); err != nil {
+				fmt.Printf("Warning: failed to reseed %s.%s: %v\n", t.Name, c.Name, err)
+			}
+		}
+	case *dialect.MSSQLDialect:
+		if _, err := dst.Exec(d.SyncIdentityQuery(t.Name)); err != nil {
+			fmt.Printf("Warning: failed to reseed IDENTITY of %s: %v\n", t.Name, err)
+		}
+	}
+}
+
+// normalizeScanned turns driver []byte into strings for non-binary columns, so
+// values can be masked and written through another driver.
+func normalizeScanned(c *schema.Column, v interface{}) interface{} {
+	b, ok := v.([]byte)
+	if !ok {
+		return v
+	}
+	t := strings.ToLower(c.DataType)
+	for _, w := range []string{"binary", "blob", "bytea", "image", "raw", "geo", "point", "polygon", "linestring"} {
+		if strings.Contains(t, w) {
+			return v
+		}
+	}
+	return string(b)
+}
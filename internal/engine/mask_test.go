@@ -0,0 +1,180 @@
+package engine_test
+
+import (
+	"db-pump/internal/engine"
+	"db-pump/internal/schema"
+	"regexp"
+	"testing"
+)
+
+func maskSchema() []*schema.Table {
+	col := func(name, dataType string, length int) *schema.Column {
+		return &schema.Column{Name: name, DataType: dataType, Length: length, IsNullable: true, Meaning: schema.AnalyzeMeaning(name, "")}
+	}
+	customerID := col("customer_id", "int", 0)
+	customerID.IsPK, customerID.IsNullable = true, false
+	email := col("email", "varchar", 100)
+	email.IsUnique = true
+	phone := col("phone", "varchar", 20)
+	phone.IsNullable = false
+	customer := &schema.Table{Name: "customer", Columns: []*schema.Column{
+		customerID, email, col("first_name", "varchar", 50), col("last_name", "varchar", 50), phone,
+	}}
+	orders := &schema.Table{
+		Name:        "orders",
+		Columns:     []*schema.Column{col("order_id", "int", 0), col("customer_email", "varchar", 100), col("status", "varchar", 10)},
+		ForeignKeys: []*schema.ForeignKey{{Column: "customer_email", RefTable: "customer", RefColumn: "email"}},
+	}
+	category := &schema.Table{Name: "category", Columns: []*schema.Column{col("category_id", "int", 0), col("name", "varchar", 25)}}
+	return []*schema.Table{customer, orders, category}
+}
+
+func TestMasker_Actions(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+	engine.Settings.Mask.Rules = []engine.MaskRuleConfig{
+		{Table: "customer", Column: "phone", Action: "null"}, // NOT NULL → fake
+		{Column: "last_*", Action: "hash"},
+	}
+	tables := maskSchema()
+	customer, orders, category := tables[0], tables[1], tables[2]
+	m := engine.NewMasker(tables)
+
+	for _, tc := range []struct {
+		table *schema.Table
+		col   int
+		want  string
+	}{
+		{customer, 0, engine.MaskKeep},
+		{customer, 1, engine.MaskFake},
+		{customer, 2, engine.MaskFake},
+		{customer, 3, engine.MaskHash},
+		{customer, 4, engine.MaskFake},
+		{orders, 1, engine.MaskFake}, // FK 컬럼은 참조하는 컬럼을 따름
+		{orders, 2, engine.MaskKeep},
+		{category, 1, engine.MaskKeep}, // 사람이 아닌 테이블의 name
+	} {
+		c := tc.table.Columns[tc.col]
+		if got := m.Action(tc.table, c); got != tc.want {
+			t.Errorf("%s.%s: got %s, want %s", tc.table.Name, c.Name, got, tc.want)
+		}
+	}
+}
+
+func TestMasker_RowKeepsForeignKeys(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+	engine.Settings.Mask.Salt = "s3cret"
+	tables := maskSchema()
+	customer, orders := tables[0], tables[1]
+
+	maskCustomer := func(m *engine.Masker) []interface{} {
+		row := []interface{}{int64(1), "hong@example.com", "길동", "홍", "010-1234-5678"}
+		m.Row(customer, customer.Columns, row)
+		return row
+	}
+	m := engine.NewMasker(tables)
+	c := maskCustomer(m)
+	o := []interface{}{int64(10), "hong@example.com", "PAID"}
+	m.Row(orders, orders.Columns, o)
+
+	if c[1] == "hong@example.com" || c[2] == "길동" {
+		t.Fatalf("PII not masked: %v", c)
+	}
+	if o[1] != c[1] {
+		t.Errorf("FK value %v does not match masked parent %v", o[1], c[1])
+	}
+	if c[0] != int64(1) || o[2] != "PAID" {
+		t.Errorf("non-PII values changed: %v %v", c, o)
+	}
+
+	// 같은 salt면 실행마다 같은 결과
+	again := maskCustomer(engine.NewMasker(tables))
+	for i := range c {
+		if c[i] != again[i] {
+			t.Errorf("column %d: %v then %v", i, c[i], again[i])
+		}
+	}
+}
+
+func TestFormatPreservingHash(t *testing.T) {
+	h := engine.FormatPreservingHash("salt", "010-1234-5678")
+	if !regexp.MustCompile(`^\d{3}-\d{4}-\d{4}$`).MatchString(h) || h == "010-1234-5678" {
+		t.Errorf("phone hash %q lost its format", h)
+	}
+	if h != engine.FormatPreservingHash("salt", "010-1234-5678") {
+		t.Error("hash is not deterministic")
+	}
+	if h := engine.FormatPreservingHash("salt", "Kim 홍길동"); !regexp.MustCompile(`^[A-Z][a-z]{2} [가-힣]{3}$`).MatchString(h) {
+		t.Errorf("name hash %q lost its format", h)
+	}
+}
+
+func TestMasker_HashedKeysStayUnique(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+	engine.Settings.Mask.Rules = []engine.MaskRuleConfig{{Table: "parent", Column: "code", Action: "hash"}}
+	code := &schema.Column{Name: "code", DataType: "int", IsPK: true}
+	parent := &schema.Table{Name: "parent", Columns: []*schema.Column{code}}
+	child := &schema.Table{
+		Name:        "child",
+		Columns:     []*schema.Column{{Name: "parent_code", DataType: "int"}},
+		ForeignKeys: []*schema.ForeignKey{{Column: "parent_code", RefTable: "parent", RefColumn: "code"}},
+	}
+	m := engine.NewMasker([]*schema.Table{parent, child})
+
+	// 1~1000은 자릿수가 같은 값끼리 해시하므로 충돌이 반드시 생김
+	seen := make(map[interface{}]int64)
+	for id := int64(1); id <= 1000; id++ {
+		row := []interface{}{id}
+		m.Row(parent, parent.Columns, row)
+		if prev, ok := seen[row[0]]; ok {
+			t.Fatalf("ids %d and %d both hash to %v", prev, id, row[0])
+		}
+		seen[row[0]] = id
+
+		ref := []interface{}{id}
+		m.Row(child, child.Columns, ref)
+		if ref[0] != row[0] {
+			t.Fatalf("FK %d hashed to %v, parent to %v", id, ref[0], row[0])
+		}
+	}
+}
+
+func TestMasker_SameValueMasksAlikeAcrossTables(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+	col := func(name string) *schema.Column {
+		return &schema.Column{Name: name, DataType: "varchar", Length: 100, IsNullable: true, Meaning: schema.AnalyzeMeaning(name, "")}
+	}
+	users := &schema.Table{Name: "users", Columns: []*schema.Column{col("email"), col("first_name")}}
+	subscribers := &schema.Table{Name: "newsletter", Columns: []*schema.Column{col("subscriber_email")}}
+	m := engine.NewMasker([]*schema.Table{users, subscribers})
+
+	// FK가 없어도 같은 이메일은 어느 테이블에서나 같은 값으로 바뀜
+	u := []interface{}{"hong@example.com", "길동"}
+	m.Row(users, users.Columns, u)
+	n := []interface{}{"hong@example.com"}
+	m.Row(subscribers, subscribers.Columns, n)
+	if u[0] == "hong@example.com" || u[0] != n[0] {
+		t.Errorf("users.email → %v, newsletter.subscriber_email → %v", u[0], n[0])
+	}
+
+	other := []interface{}{"kim@example.com", "길동"}
+	m.Row(users, users.Columns, other)
+	if other[0] == u[0] {
+		t.Errorf("different emails both masked to %v", u[0])
+	}
+	if other[1] != u[1] {
+		t.Errorf("same first name masked to %v and %v", u[1], other[1])
+	}
+}
+
+func TestValidateMaskSalt(t *testing.T) {
+	// 기본 설정(빈 salt)으로는 마스킹하지 않음: 키 없는 해시는 후보 값을 대입해 되돌릴 수 있다
+	if err := engine.ValidateMaskSalt(engine.DefaultConfig().Mask); err == nil {
+		t.Error("default settings: empty salt accepted")
+	}
+	if err := engine.ValidateMaskSalt(engine.MaskConfig{Salt: "  "}); err == nil {
+		t.Error("blank salt accepted")
+	}
+	if err := engine.ValidateMaskSalt(engine.MaskConfig{Salt: "s3cret"}); err != nil {
+		t.Errorf("salt rejected: %v", err)
+	}
+}
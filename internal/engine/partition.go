@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"db-pump/internal/schema"
+)
+
+// choosePartition aims the current row at one partition of the table, cycling through
+// them so rows are spread evenly. Rows are inserted into the table itself and the
+// database routes them; only the partition key value is chosen here.
+func choosePartition(table *schema.Table, index int) {
+	if len(table.Partitions) == 0 {
+		return
+	}
+	if index <= 0 {
+		index = seededRand.Intn(len(table.Partitions))
+	}
+	currentRow.partition = table.Partitions[index%len(table.Partitions)]
+	currentRow.partitionKey = table.PartitionKey
+}
+
+// isPartitionKey reports whether col is the key of the partition the row is aimed at.
+func isPartitionKey(col *schema.Column) bool {
+	return currentRow.partition != nil && strings.EqualFold(col.Name, currentRow.partitionKey)
+}
+
+// generatePartitionValue returns a key value inside the row's partition for LIST partitions
+// and numeric RANGE keys. Date keys go through the date branch with partitionWindow applied.
+func generatePartitionValue(col *schema.Column) (interface{}, bool) {
+	if !isPartitionKey(col) {
+		return nil, false
+	}
+	p := currentRow.partition
+	if len(p.Values) > 0 {
+		return p.Values[seededRand.Intn(len(p.Values))], true
+	}
+
+	dt := strings.ToLower(col.DataType)
+	if !strings.Contains(dt, "int") && !strings.Contains(dt, "number") {
+		return nil, false
+	}
+	lo, hi := intRange(col)
+	if from, err := strconv.ParseInt(p.From, 10, 64); err == nil && from > lo {
+		lo = from
+	}
+	if to, err := strconv.ParseInt(p.To, 10, 64); err == nil && to-1 < hi {
+		hi = to - 1
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return randInt64(lo, hi), true
+}
+
+// partitionWindow narrows [from, to] to the date range of the row's partition.
+// An open-ended partition keeps the configured window on that side; when the two don't
+// overlap, the partition wins and a one-year window is placed against its bound.
+func partitionWindow(from, to time.Time) (time.Time, time.Time) {
+	p := currentRow.partition
+	pFrom, hasFrom := parsePartitionTime(p.From)
+	pTo, hasTo := parsePartitionTime(p.To)
+	if hasTo {
+		pTo = pTo.Add(-time.Second) // upper bound is exclusive
+	}
+
+	switch {
+	case hasFrom && hasTo:
+		return pFrom, pTo
+	case hasFrom:
+		if to.Before(pFrom) {
+			return pFrom, pFrom.AddDate(1, 0, 0)
+		}
+		if from.Before(pFrom) {
+			from = pFrom
+		}
+	case hasTo:
+		if from.After(pTo) {
+			return pTo.AddDate(-1, 0, 0), pTo
+		}
+		if to.After(pTo) {
+			to = pTo
+		}
+	}
+	return from, to
+}
+
+// parsePartitionTime reads a partition bound. Bounds with an offset ("2022-01-01 00:00:00+00",
+// timestamptz keys) are instants; bounds without one are wall clock in settings.timezone.
+func parsePartitionTime(s string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02 15:04:05-07", "2006-01-02 15:04:05-07:00"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.In(dateLocation()), true
+		}
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return parseTimeValue(t)
+		}
+	}
+	return time.Time{}, false
+}
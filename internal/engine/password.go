@@ -0,0 +1,199 @@
+package engine
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"db-pump/internal/schema"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordConfig controls password columns: every generated user gets the hash of
+// the same known plaintext, so seeded accounts can log in.
+type PasswordConfig struct {
+	Plaintext  string                 `mapstructure:"plaintext"`
+	Algorithm  string                 `mapstructure:"algorithm"`   // "bcrypt" (default), "argon2id", "sha256", "sha512", "plain"
+	BcryptCost int                    `mapstructure:"bcrypt_cost"` // 0 = bcrypt.DefaultCost
+	Columns    []PasswordColumnConfig `mapstructure:"columns"`
+}
+
+// PasswordColumnConfig overrides the algorithm or plaintext of one column ("table" may be omitted).
+type PasswordColumnConfig struct {
+	Table     string `mapstructure:"table"`
+	Column    string `mapstructure:"column"`
+	Algorithm string `mapstructure:"algorithm"`
+	Plaintext string `mapstructure:"plaintext"`
+}
+
+// Password hash algorithms.
+const (
+	HashBcrypt   = "bcrypt"   // $2a$10$... (60 chars)
+	HashArgon2id = "argon2id" // $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash> (PHC string)
+	HashSHA256   = "sha256"   // hex (64 chars)
+	HashSHA512   = "sha512"   // hex (128 chars)
+	HashPlain    = "plain"    // the plaintext itself
+)
+
+// DefaultPassword is the plaintext used when settings.password.plaintext is empty.
+const DefaultPassword = "Passw0rd!"
+
+// passwordHashes caches one hash per algorithm and plaintext: bcrypt and argon2 are
+// deliberately slow, and any salted hash of the plaintext verifies the same.
+var passwordHashes = make(map[string]string)
+
+// warnedPasswordColumns remembers the warnings already printed per column.
+var warnedPasswordColumns = make(map[string]bool)
+
+// isPasswordColumn reports whether a string column stores a password (hash), as
+// opposed to e.g. password_changed_at or password_yn.
+func isPasswordColumn(meaning string) bool {
+	words := strings.Fields(meaning)
+	found := false
+	for _, w := range words {
+		switch {
+		case strings.Contains(w, "password"): // password, passwordhash
+			found = true
+		case w == "date" || w == "time" || w == "at" || w == "yesno" || w == "count" ||
+			w == "flag" || w == "hint" || w == "question":
+			return false
+		}
+	}
+	return found
+}
+
+// passwordSettings resolves the algorithm and plaintext of a column.
+func passwordSettings(col *schema.Column, tableName string) (string, string) {
+	alg, plain := Settings.Password.Algorithm, Settings.Password.Plaintext
+	for _, c := range Settings.Password.Columns {
+		if strings.EqualFold(c.Column, col.Name) && (c.Table == "" || strings.EqualFold(c.Table, tableName)) {
+			if c.Algorithm != "" {
+				alg = c.Algorithm
+			}
+			if c.Plaintext != "" {
+				plain = c.Plaintext
+			}
+			break
+		}
+	}
+	if alg == "" {
+		alg = HashBcrypt
+	}
+	if plain == "" {
+		plain = DefaultPassword
+	}
+	return strings.ToLower(alg), plain
+}
+
+// ValidatePasswordAlgorithms reports an unknown algorithm in settings.password.
+func ValidatePasswordAlgorithms(c PasswordConfig) error {
+	algs := []string{c.Algorithm}
+	for _, col := range c.Columns {
+		algs = append(algs, col.Algorithm)
+	}
+	for _, alg := range algs {
+		switch strings.ToLower(alg) {
+		case "", HashBcrypt, HashArgon2id, HashSHA256, HashSHA512, HashPlain:
+		default:
+			return fmt.Errorf("unknown password algorithm %q (supported: bcrypt, argon2id, sha256, sha512, plain)", alg)
+		}
+	}
+	return nil
+}
+
+// generatePassword returns the hash of the configured plaintext for a password column.
+// UNIQUE columns get a freshly salted hash per row; unsalted algorithms would repeat
+// one value there, so such columns use bcrypt instead.
+func generatePassword(col *schema.Column, tableName string) string {
+	alg, plain := passwordSettings(col, tableName)
+	if col.IsUnique && (alg == HashSHA256 || alg == HashSHA512 || alg == HashPlain) {
+		if !warnedPasswordColumns[tableName+"."+col.Name+"#unique"] {
+			warnedPasswordColumns[tableName+"."+col.Name+"#unique"] = true
+			fmt.Printf("[Password] Warning: %s.%s is UNIQUE but %s gives every row the same value, using %s\n",
+				tableName, col.Name, alg, HashBcrypt)
+		}
+		alg = HashBcrypt
+	}
+	key := alg + "\x00" + plain
+	h, ok := passwordHashes[key]
+	if !ok || col.IsUnique {
+		var err error
+		if h, err = HashPassword(alg, plain); err != nil {
+			fmt.Printf("[Password] Warning: %v, using %s\n", err, HashBcrypt)
+			alg = HashBcrypt
+			h, _ = HashPassword(alg, plain)
+		}
+		passwordHashes[key] = h
+	}
+	if col.Length > 0 && len(h) > col.Length && !warnedPasswordColumns[tableName+"."+col.Name+"#length"] {
+		warnedPasswordColumns[tableName+"."+col.Name+"#length"] = true
+		fmt.Printf("[Password] Warning: %s.%s (%d) is too short for a %s hash (%d), values are truncated\n",
+			tableName, col.Name, col.Length, alg, len(h))
+	}
+	return truncate(h, col.Length)
+}
+
+// HashPassword hashes plain with the given algorithm.
+func HashPassword(alg, plain string) (string, error) {
+	switch alg {
+	case HashBcrypt:
+		cost := Settings.Password.BcryptCost
+		if cost == 0 {
+			cost = bcrypt.DefaultCost
+		}
+		b, err := bcrypt.GenerateFromPassword([]byte(plain), cost)
+		return string(b), err
+	case HashArgon2id:
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		const memory, time, threads = 64 * 1024, 3, 2
+		key := argon2.IDKey([]byte(plain), salt, time, memory, threads, 32)
+		enc := base64.RawStdEncoding
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+			argon2.Version, memory, time, threads, enc.EncodeToString(salt), enc.EncodeToString(key)), nil
+	case HashSHA256:
+		sum := sha256.Sum256([]byte(plain))
+		return hex.EncodeToString(sum[:]), nil
+	case HashSHA512:
+		sum := sha512.Sum512([]byte(plain))
+		return hex.EncodeToString(sum[:]), nil
+	case HashPlain:
+		return plain, nil
+	}
+	return "", fmt.Errorf("unknown password algorithm %q", alg)
+}
+
+// isSecretColumn reports whether a string column holds a random secret: API keys,
+// tokens, salts.
+func isSecretColumn(colName string) bool {
+	for _, w := range strings.FieldsFunc(colName, func(r rune) bool { return r == '_' || r == ' ' }) {
+		switch w {
+		case "token", "secret", "apikey", "salt", "nonce":
+			return true
+		case "key":
+			if strings.Contains(colName, "api") || strings.Contains(colName, "secret") || strings.Contains(colName, "access") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generateSecret returns random hex filling the column (at most 64 characters).
+func generateSecret(col *schema.Column) string {
+	n := 64
+	if col.Length > 0 && col.Length < n {
+		n = col.Length
+	}
+	b := make([]byte, (n+1)/2)
+	seededRand.Read(b)
+	return hex.EncodeToString(b)[:n]
+}
@@ -0,0 +1,72 @@
+package engine_test
+
+import (
+	"crypto/sha256"
+	"db-pump/internal/engine"
+	"db-pump/internal/schema"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestGenerateValue_PasswordHash(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+	engine.Settings.Password = engine.PasswordConfig{
+		Plaintext:  "test1234",
+		BcryptCost: bcrypt.MinCost,
+		Columns: []engine.PasswordColumnConfig{
+			{Table: "legacy_user", Column: "pwd", Algorithm: "sha256"},
+			{Column: "admin_pw", Algorithm: "argon2id"},
+		},
+	}
+	column := func(name string, length int) *schema.Column {
+		return &schema.Column{Name: name, DataType: "varchar", Length: length, Meaning: schema.AnalyzeMeaning(name, "")}
+	}
+
+	// 기본값: bcrypt, 설정한 평문으로 로그인 가능
+	h := engine.GenerateValue(column("passwd", 255), "member").(string)
+	if err := bcrypt.CompareHashAndPassword([]byte(h), []byte("test1234")); err != nil {
+		t.Fatalf("bcrypt hash %q does not verify: %v", h, err)
+	}
+
+	// 컬럼별 알고리즘
+	sum := sha256.Sum256([]byte("test1234"))
+	if h := engine.GenerateValue(column("pwd", 64), "legacy_user"); h != hex.EncodeToString(sum[:]) {
+		t.Errorf("sha256 column: got %v", h)
+	}
+	if h := engine.GenerateValue(column("admin_pw", 255), "admin").(string); !strings.HasPrefix(h, "$argon2id$v=19$") {
+		t.Errorf("argon2id column: got %q", h)
+	}
+
+	// 비밀번호 힌트 같은 컬럼은 해시가 아님
+	if h := engine.GenerateValue(column("password_hint", 100), "member").(string); strings.HasPrefix(h, "$2") {
+		t.Errorf("password_hint got a hash: %q", h)
+	}
+}
+
+func TestValidatePasswordAlgorithms(t *testing.T) {
+	if err := engine.ValidatePasswordAlgorithms(engine.PasswordConfig{Algorithm: "SHA256"}); err != nil {
+		t.Errorf("sha256: %v", err)
+	}
+	bad := engine.PasswordConfig{Columns: []engine.PasswordColumnConfig{{Column: "pwd", Algorithm: "md5"}}}
+	if err := engine.ValidatePasswordAlgorithms(bad); err == nil {
+		t.Error("md5 was accepted")
+	}
+}
+
+func TestGenerateValue_UniquePasswordColumnsDiffer(t *testing.T) {
+	defer func() { engine.Settings = engine.DefaultConfig() }()
+	engine.Settings.Password = engine.PasswordConfig{Algorithm: "sha256", BcryptCost: bcrypt.MinCost}
+	col := &schema.Column{Name: "password", DataType: "varchar", Length: 255, IsUnique: true, Meaning: schema.AnalyzeMeaning("password", "")}
+
+	// 솔트 없는 해시는 모든 행이 같은 값이 되므로 UNIQUE 컬럼에는 bcrypt를 씀
+	a, b := engine.GenerateValue(col, "member").(string), engine.GenerateValue(col, "member").(string)
+	if a == b {
+		t.Fatalf("two rows got the same hash %q", a)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(a), []byte(engine.DefaultPassword)); err != nil {
+		t.Errorf("%q does not verify: %v", a, err)
+	}
+}
@@ -0,0 +1,259 @@
+package engine
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"db-pump/internal/schema"
+
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// PersonaConfig controls the person each generated row describes.
+type PersonaConfig struct {
+	MinAge       int      `mapstructure:"min_age"`
+	MaxAge       int      `mapstructure:"max_age"`
+	EmailDomains []string `mapstructure:"email_domains"` // empty = the locale's common mail providers
+}
+
+// Person column kinds, derived from column names and meanings.
+const (
+	personFirstName = "first"
+	personLastName  = "last"
+	personEmail     = "email"
+	personUsername  = "username"
+	personGender    = "gender"
+	personBirth     = "birth"
+	personAge       = "age"
+)
+
+// personColumn classifies first_name, last_name, email, username, gender, birth date
+// and age columns. Full-name columns are left to the generic "name" rule.
+func personColumn(colName, meaning string) string {
+	words := strings.Fields(meaning)
+	has := func(ws ...string) bool {
+		for _, x := range words {
+			for _, w := range ws {
+				if x == w {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	last := ""
+	if len(words) > 0 {
+		last = words[len(words)-1]
+	}
+
+	switch {
+	case strings.Contains(meaning, "email") || strings.Contains(colName, "email"):
+		return personEmail
+	case has("gender", "sex"):
+		return personGender
+	case has("dob", "birth", "birthday", "birthdate"):
+		return personBirth
+	case last == "age":
+		return personAge
+	case has("username", "userid", "login", "nickname", "nick") && !has("date", "time", "at", "ip", "count"),
+		last == "name" && has("user", "login", "nick", "account"):
+		return personUsername
+	case has("firstname", "fname", "givenname") || (last == "name" && has("first", "given")):
+		return personFirstName
+	case has("lastname", "lname", "surname", "familyname") || (last == "name" && has("last", "family", "sur")):
+		return personLastName
+	}
+	return ""
+}
+
+// persona is the person a row describes. Name, email, username, gender, birth date,
+// age and 주민등록번호 columns of the same row all draw from it.
+type persona struct {
+	loc       *Locale
+	male      bool
+	lastName  string
+	firstName string
+	birth     time.Time
+	login     string // romanized local part shared by email and username
+}
+
+// rowPersona returns the current row's persona, creating it on first use.
+func rowPersona() *persona {
+	if loc := activeLocale(); currentRow.persona == nil || currentRow.persona.loc != loc {
+		p := &persona{loc: loc, male: seededRand.Intn(2) == 0}
+		p.lastName = loc.LastName()
+		p.firstName = loc.GivenName(p.male)
+		currentRow.persona = p
+	}
+	return currentRow.persona
+}
+
+func (p *persona) fullName() string {
+	return p.loc.JoinName(p.lastName, p.firstName)
+}
+
+// birthDate draws the birth date once per row from settings.persona min_age..max_age.
+// A birth column with its own date_columns range is honoured when it comes first.
+func (p *persona) birthDate(col *schema.Column, tableName string) time.Time {
+	if !p.birth.IsZero() {
+		return p.birth
+	}
+	now := time.Now().In(dateLocation())
+	from, to := now.AddDate(-Settings.Persona.MaxAge, 0, 0), now.AddDate(-Settings.Persona.MinAge, 0, 0)
+	if col != nil {
+		if _, ok := dateColumnRange(col, tableName); ok {
+			from, to = dateWindowFor(col, tableName)
+		}
+	}
+	if to.Before(from) {
+		from, to = to, from
+	}
+	b := from.Add(time.Duration(seededRand.Int63n(int64(to.Sub(from)) + 1)))
+	p.birth = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, dateLocation())
+	return p.birth
+}
+
+// age is the persona's age in whole years.
+func (p *persona) age() int {
+	b := p.birthDate(nil, "")
+	now := time.Now().In(dateLocation())
+	age := now.Year() - b.Year()
+	if now.Before(b.AddDate(age, 0, 0)) {
+		age--
+	}
+	return age
+}
+
+// username is the romanized name, e.g. "minjun.kim" or "kim_minjun87".
+func (p *persona) username() string {
+	if p.login != "" {
+		return p.login
+	}
+	first, last := p.loc.Romanize(p.firstName, false), p.loc.Romanize(p.lastName, true)
+	if first == "" || last == "" {
+		p.login = strings.ToLower(gofakeit.Username())
+		return p.login
+	}
+	switch seededRand.Intn(4) {
+	case 0:
+		p.login = first + "." + last
+	case 1:
+		p.login = last + "_" + first
+	case 2:
+		p.login = first[:1] + last
+	default:
+		p.login = first + last
+	}
+	if seededRand.Intn(2) == 0 {
+		p.login += p.birthDate(nil, "").Format("06")
+	}
+	return p.login
+}
+
+func (p *persona) email() string {
+	domains := Settings.Persona.EmailDomains
+	if len(domains) == 0 {
+		domains = p.loc.EmailDomains
+	}
+	if len(domains) == 0 {
+		return p.username() + "@" + gofakeit.DomainName()
+	}
+	return p.username() + "@" + domains[seededRand.Intn(len(domains))]
+}
+
+// genderValue renders the persona's gender for the column: the matching ENUM value,
+// "M"/"F" for one-character columns, 1/2 for numeric ones, or the locale's word.
+func (p *persona) genderValue(col *schema.Column, numeric bool) interface{} {
+	if len(col.EnumValues) > 0 {
+		tokens := femaleTokens
+		if p.male {
+			tokens = maleTokens
+		}
+		for _, v := range col.EnumValues {
+			if tokens[strings.ToLower(strings.TrimSpace(v))] {
+				return v
+			}
+		}
+		return col.EnumValues[seededRand.Intn(len(col.EnumValues))]
+	}
+	if numeric {
+		if p.male {
+			return 1
+		}
+		return 2
+	}
+	if col.Length == 1 || p.loc.Genders[0] == "" {
+		if p.male {
+			return "M"
+		}
+		return "F"
+	}
+	if p.male {
+		return truncate(p.loc.Genders[0], col.Length)
+	}
+	return truncate(p.loc.Genders[1], col.Length)
+}
+
+var (
+	maleTokens   = map[string]bool{"m": true, "male": true, "man": true, "men": true, "1": true, "남": true, "남자": true, "남성": true, "男": true, "男性": true}
+	femaleTokens = map[string]bool{"f": true, "female": true, "woman": true, "women": true, "2": true, "여": true, "여자": true, "여성": true, "女": true, "女性": true}
+)
+
+// Romanize spells a name in lowercase ASCII for emails and usernames, or returns "".
+// Hangul follows the Revised Romanization (family names use their customary
+// spelling: 김 → kim, 이 → lee); Japanese and Chinese use the pack's readings.
+func (l *Locale) Romanize(name string, family bool) string {
+	if r, ok := l.Readings[name]; ok {
+		return r
+	}
+	if family {
+		if r, ok := koreanFamilyNames[name]; ok {
+			return r
+		}
+	}
+	var b strings.Builder
+	for _, c := range name {
+		switch {
+		case c >= 0xAC00 && c <= 0xD7A3:
+			b.WriteString(romanizeSyllable(c))
+		case c < unicode.MaxASCII && unicode.IsLetter(c):
+			b.WriteRune(unicode.ToLower(c))
+		default:
+			r, ok := l.Readings[string(c)]
+			if !ok {
+				continue
+			}
+			b.WriteString(r)
+		}
+	}
+	return b.String()
+}
+
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulMedials  = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	hangulFinals   = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+)
+
+func romanizeSyllable(c rune) string {
+	i := int(c - 0xAC00)
+	return hangulInitials[i/588] + hangulMedials[(i%588)/28] + hangulFinals[i%28]
+}
+
+// koreanFamilyNames are customary spellings that differ from the Revised Romanization.
+var koreanFamilyNames = map[string]string{
+	"김": "kim", "이": "lee", "박": "park", "최": "choi", "정": "jung", "조": "cho", "윤": "yoon", "임": "lim",
+	"오": "oh", "신": "shin", "권": "kwon", "안": "ahn", "류": "ryu", "유": "yoo", "고": "ko", "문": "moon",
+	"노": "noh", "심": "shim", "곽": "kwak", "성": "sung", "주": "joo", "우": "woo", "구": "koo", "엄": "um",
+	"천": "chun", "현": "hyun", "변": "byun", "추": "choo", "선": "sun", "명": "myung", "기": "ki", "금": "keum",
+	"육": "yook", "국": "kook", "경": "kyung", "부": "boo", "황보": "hwangbo", "선우": "sunwoo",
+}
+
+// birthString formats a birth date for CHAR/VARCHAR columns ("19900101" when 8 characters fit exactly).
+func birthString(b time.Time, col *schema.Column) string {
+	if col.Length == 8 {
+		return b.Format("20060102")
+	}
+	return truncate(b.Format("2006-01-02"), col.Length)
+}
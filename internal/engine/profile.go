@@ -0,0 +1,312 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"db-pump/internal/schema"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ProfileConfig points fill at a profile written by `db-pump profile`.
+type ProfileConfig struct {
+	File  string  `mapstructure:"file"`
+	Scale float64 `mapstructure:"scale"` // rows per table = profiled rows × scale; 0 = use default_count
+}
+
+// Profile is the statistical shape of a source database. It holds distributions
+// rather than rows: the only literal values are frequent categories of non-PII columns.
+type Profile struct {
+	Driver    string                   `yaml:"driver"`
+	Schema    string                   `yaml:"schema"`
+	CreatedAt time.Time                `yaml:"created_at"`
+	Tables    map[string]*TableProfile `yaml:"tables"`
+}
+
+type TableProfile struct {
+	Rows    int64                     `yaml:"rows"`
+	Sampled int                       `yaml:"sampled"` // rows the statistics were computed from
+	Columns map[string]*ColumnProfile `yaml:"columns,omitempty"`
+	FanOut  map[string]*FanOut        `yaml:"fan_out,omitempty"` // FK column → children per parent row
+}
+
+type ColumnProfile struct {
+	NullRatio float64    `yaml:"null_ratio"`
+	Distinct  int        `yaml:"distinct"` // distinct non-NULL values in the sample
+	Top       []TopValue `yaml:"top,omitempty"`
+	Numbers   *Histogram `yaml:"numbers,omitempty"`
+	Dates     *Histogram `yaml:"dates,omitempty"` // wall-clock Unix seconds
+	Lengths   *Histogram `yaml:"lengths,omitempty"`
+}
+
+// TopValue is a frequent value and its share of the non-NULL rows.
+type TopValue struct {
+	Value string  `yaml:"value"`
+	Ratio float64 `yaml:"ratio"`
+}
+
+// FanOut describes how many child rows reference each parent row.
+type FanOut struct {
+	RefTable  string     `yaml:"ref_table"`
+	ZeroRatio float64    `yaml:"zero_ratio"` // parent rows without any child
+	Children  *Histogram `yaml:"children,omitempty"`
+}
+
+// Histogram is an equi-width histogram over [Min, Max]. Integer histograms
+// (lengths, fan-out, integer columns) sample whole numbers.
+type Histogram struct {
+	Min     float64 `yaml:"min"`
+	Max     float64 `yaml:"max"`
+	Integer bool    `yaml:"integer,omitempty"`
+	Counts  []int   `yaml:"counts"`
+}
+
+func newHistogram(values []float64, buckets int, integer bool) *Histogram {
+	if len(values) == 0 {
+		return nil
+	}
+	h := &Histogram{Min: values[0], Max: values[0], Integer: integer}
+	for _, v := range values {
+		h.Min, h.Max = math.Min(h.Min, v), math.Max(h.Max, v)
+	}
+	if integer && int(h.Max-h.Min)+1 < buckets {
+		buckets = int(h.Max-h.Min) + 1
+	}
+	if h.Min == h.Max || buckets < 1 {
+		buckets = 1
+	}
+	h.Counts = make([]int, buckets)
+	w := h.width()
+	for _, v := range values {
+		i := int((v - h.Min) / w)
+		if i >= buckets {
+			i = buckets - 1
+		}
+		h.Counts[i]++
+	}
+	return h
+}
+
+func (h *Histogram) width() float64 {
+	span := h.Max - h.Min
+	if h.Integer {
+		span++
+	}
+	if span <= 0 {
+		return 1
+	}
+	return span / float64(len(h.Counts))
+}
+
+// Sample draws a value: a bucket by its count, then uniformly within the bucket.
+func (h *Histogram) Sample() float64 {
+	total := 0
+	for _, c := range h.Counts {
+		total += c
+	}
+	if total == 0 {
+		return h.Min
+	}
+	r := seededRand.Intn(total)
+	for i, c := range h.Counts {
+		if r < c {
+			v := h.Min + (float64(i)+seededRand.Float64())*h.width()
+			if h.Integer {
+				v = math.Floor(v)
+			}
+			return math.Min(v, h.Max)
+		}
+		r -= c
+	}
+	return h.Max
+}
+
+// activeProfile is the profile loaded for fill (nil = generate from column semantics only).
+var activeProfile *Profile
+
+// fanOutWeights caches each parent row's weight per "table.column", drawn from the FK fan-out.
+var fanOutWeights = make(map[string][]float64)
+
+// LoadProfile reads a profile file for fill; an empty path clears it.
+func LoadProfile(path string) error {
+	activeProfile = nil
+	fanOutWeights = make(map[string][]float64)
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	p := &Profile{}
+	if err := yaml.Unmarshal(data, p); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	activeProfile = p
+	return nil
+}
+
+// SaveProfile writes a profile as YAML.
+func SaveProfile(p *Profile, path string) error {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func tableProfile(table string) *TableProfile {
+	if activeProfile == nil {
+		return nil
+	}
+	if tp, ok := activeProfile.Tables[table]; ok {
+		return tp
+	}
+	for name, tp := range activeProfile.Tables {
+		if strings.EqualFold(name, table) {
+			return tp
+		}
+	}
+	return nil
+}
+
+func columnProfile(table, column string) *ColumnProfile {
+	tp := tableProfile(table)
+	if tp == nil {
+		return nil
+	}
+	if cp, ok := tp.Columns[column]; ok {
+		return cp
+	}
+	for name, cp := range tp.Columns {
+		if strings.EqualFold(name, column) {
+			return cp
+		}
+	}
+	return nil
+}
+
+// profiledCount is the row target for a table: profiled rows × settings.profile.scale,
+// or count when no profile or scale is set.
+func profiledCount(table string, count int) int {
+	tp := tableProfile(table)
+	if tp == nil || Settings.Profile.Scale <= 0 {
+		return count
+	}
+	return int(math.Round(float64(tp.Rows) * Settings.Profile.Scale))
+}
+
+// profileValue draws a column value from the profile: NULLs at the profiled ratio,
+// frequent categories, numeric/date histograms and free-text lengths.
+// Columns whose values are tied to the rest of the row (persona, identifiers, partition
+// key, ordered dates) only take the NULL ratio.
+func profileValue(col *schema.Column, table *schema.Table) (interface{}, bool) {
+	cp := columnProfile(table.Name, col.Name)
+	if cp == nil || col.IsPK {
+		return nil, false
+	}
+	if col.IsNullable && cp.NullRatio > 0 && seededRand.Float64() < cp.NullRatio {
+		return nil, true
+	}
+	colName := strings.ToLower(col.Name)
+	if isPartitionKey(col) || isTemporalDependent(col, table.Name) || col.ElementType != "" ||
+		personColumn(colName, col.Meaning) != "" || identifierKind(col.Meaning) != "" {
+		return nil, false
+	}
+
+	if !col.IsUnique {
+		r := seededRand.Float64()
+		for _, tv := range cp.Top {
+			if r < tv.Ratio {
+				return tv.Value, true
+			}
+			r -= tv.Ratio
+		}
+	}
+
+	dataType := strings.ToLower(col.DataType)
+	switch {
+	case cp.Dates != nil && isDateType(col.DataType):
+		t := time.Unix(int64(cp.Dates.Sample()), 0).UTC()
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, dateLocation())
+		return formatTime(t, dataType), true
+	case cp.Numbers != nil:
+		v := cp.Numbers.Sample()
+		if strings.Contains(dataType, "int") {
+			return int64(math.Round(v)), true
+		}
+		if col.Scale > 0 {
+			pow := math.Pow10(col.Scale)
+			v = math.Round(v*pow) / pow
+		}
+		return v, true
+	case cp.Lengths != nil && isFreeText(col):
+		return generateText(col, table.Name, int(cp.Lengths.Sample())), true
+	}
+	return nil, false
+}
+
+// isFreeText reports whether a column holds prose, whose length the profile can shape.
+func isFreeText(col *schema.Column) bool {
+	for _, w := range []string{"title", "subject", "description", "content", "comment", "text", "memo", "note", "remark", "message"} {
+		if strings.Contains(col.Meaning, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// fanOutIndex picks a parent row for an FK column so that children per parent follow
+// the profiled fan-out (some parents get many children, some none).
+func fanOutIndex(t *schema.Table, fk *schema.ForeignKey, n int) (int, bool) {
+	tp := tableProfile(t.Name)
+	if tp == nil || n == 0 {
+		return 0, false
+	}
+	fo := tp.FanOut[fk.Column]
+	if fo == nil || fo.Children == nil {
+		return 0, false
+	}
+
+	key := t.Name + "." + fk.Column
+	cum, ok := fanOutWeights[key]
+	if !ok || len(cum) != n {
+		cum = make([]float64, n)
+		total := 0.0
+		for i := range cum {
+			if seededRand.Float64() >= fo.ZeroRatio {
+				total += fo.Children.Sample()
+			}
+			cum[i] = total
+		}
+		fanOutWeights[key] = cum
+	}
+	if cum[n-1] == 0 {
+		return 0, false
+	}
+	r := seededRand.Float64() * cum[n-1]
+	return sort.Search(n, func(i int) bool { return cum[i] > r }), true
+}
+
+// isPIIColumn reports whether a column identifies a person (names, contacts, addresses,
+// identifiers, credentials). Literal values of such columns never leave the source DB.
+func isPIIColumn(col *schema.Column) bool {
+	colName := strings.ToLower(col.Name)
+	if personColumn(colName, col.Meaning) != "" || identifierKind(col.Meaning) != "" {
+		return true
+	}
+	for _, w := range []string{"name", "phone", "email", "address", "zipcode", "postal", "password", "ip"} {
+		for _, x := range strings.Fields(col.Meaning) {
+			if x == w {
+				return true
+			}
+		}
+	}
+	return strings.Contains(colName, "name") || strings.Contains(colName, "phone") ||
+		strings.Contains(colName, "email") || strings.Contains(colName, "addr")
+}
@@ -0,0 +1,65 @@
+package engine_test
+
+import (
+	"db-pump/internal/engine"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestHistogram_Sample(t *testing.T) {
+	// 값의 90%가 첫 구간 [0, 10)에 몰려 있음
+	h := &engine.Histogram{Min: 0, Max: 99, Integer: true, Counts: []int{90, 0, 0, 0, 0, 0, 0, 0, 0, 10}}
+	low := 0
+	for i := 0; i < 2000; i++ {
+		v := h.Sample()
+		if v < h.Min || v > h.Max || v != math.Floor(v) {
+			t.Fatalf("sample %v outside [%v, %v] or not an integer", v, h.Min, h.Max)
+		}
+		if v >= 20 && v < 90 {
+			t.Fatalf("sample %v from an empty bucket", v)
+		}
+		if v < 10 {
+			low++
+		}
+	}
+	if low < 1600 {
+		t.Errorf("only %d/2000 samples in the 90%% bucket", low)
+	}
+}
+
+func TestProfile_SaveLoad(t *testing.T) {
+	defer engine.LoadProfile("")
+
+	path := filepath.Join(t.TempDir(), "prod.profile.yaml")
+	p := &engine.Profile{Driver: "postgres", Tables: map[string]*engine.TableProfile{
+		"orders": {
+			Rows: 1000, Sampled: 1000,
+			Columns: map[string]*engine.ColumnProfile{
+				"status": {NullRatio: 0.1, Distinct: 2, Top: []engine.TopValue{{Value: "PAID", Ratio: 0.7}, {Value: "NEW", Ratio: 0.3}}},
+				"amount": {Distinct: 900, Numbers: &engine.Histogram{Min: 1, Max: 500, Counts: []int{800, 200}}},
+			},
+			FanOut: map[string]*engine.FanOut{"customer_id": {RefTable: "customer", ZeroRatio: 0.2, Children: &engine.Histogram{Min: 1, Max: 8, Integer: true, Counts: []int{5, 3}}}},
+		},
+	}}
+	if err := engine.SaveProfile(p, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.LoadProfile(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	var got engine.Profile
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if o := got.Tables["orders"]; o == nil || o.Columns["status"].Top[0].Value != "PAID" || o.FanOut["customer_id"].Children.Counts[1] != 3 {
+		t.Errorf("profile did not round-trip: %+v", got.Tables["orders"])
+	}
+	if err := engine.LoadProfile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("loading a missing profile should fail")
+	}
+}
@@ -0,0 +1,244 @@
+package engine
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/schema"
+)
+
+// ProfileOptions controls how `db-pump profile` samples the source database.
+type ProfileOptions struct {
+	SampleRows  int // rows read per table
+	TopK        int // frequent values kept per column
+	MinCount    int // a value is kept only if at least this many sampled rows share it
+	MaxDistinct int // values are kept only for columns with at most this many distinct values (codes, enums); 0 = no limit
+	Buckets     int // histogram buckets
+}
+
+// DefaultProfileOptions returns the built-in sampling settings.
+func DefaultProfileOptions() ProfileOptions {
+	return ProfileOptions{SampleRows: 100000, TopK: 20, MinCount: 5, MaxDistinct: 50, Buckets: 20}
+}
+
+// maxTrackedDistinct bounds the per-column value counts kept while sampling.
+const maxTrackedDistinct = 100000
+
+// BuildProfile samples every table with plain SELECTs (nothing is written, so it can
+// run against a read-only replica) and returns their statistics.
+func BuildProfile(db *sql.DB, d dialect.Dialect, tables []*schema.Table, opts ProfileOptions, onTable func(table string)) (*Profile, error) {
+	p := &Profile{CreatedAt: time.Now(), Tables: make(map[string]*TableProfile)}
+	for _, t := range tables {
+		tp, err := profileTable(db, d, t, opts)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", t.Name, err)
+		}
+		p.Tables[t.Name] = tp
+		if onTable != nil {
+			onTable(t.Name)
+		}
+	}
+
+	// Parents without children, now that every parent's row count is known
+	for _, t := range tables {
+		for col, fo := range p.Tables[t.Name].FanOut {
+			parents, ok := p.Tables[fo.RefTable]
+			if !ok {
+				var n int64
+				db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", d.QuoteIdent(fo.RefTable))).Scan(&n)
+				parents = &TableProfile{Rows: n}
+			}
+			if parents.Rows > 0 {
+				withChildren := float64(p.Tables[t.Name].Columns[col].Distinct)
+				if tp := p.Tables[t.Name]; tp.Sampled > 0 && int64(tp.Sampled) < tp.Rows {
+					withChildren *= float64(tp.Rows) / float64(tp.Sampled) // rough: the sample saw a fraction of the parents
+				}
+				if z := 1 - withChildren/float64(parents.Rows); z > 0 {
+					fo.ZeroRatio = z
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// columnStats accumulates one column while scanning the sample.
+type columnStats struct {
+	col    *schema.Column
+	kind   string // "number", "date", "string" or "" (distinct/NULLs only)
+	nulls  int
+	counts map[string]int
+	values []float64 // numbers, dates (Unix seconds) or string lengths
+}
+
+func profileTable(db *sql.DB, d dialect.Dialect, t *schema.Table, opts ProfileOptions) (*TableProfile, error) {
+	tp := &TableProfile{Columns: make(map[string]*ColumnProfile)}
+	if err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", d.QuoteIdent(t.Name))).Scan(&tp.Rows); err != nil {
+		return nil, err
+	}
+	if tp.Rows == 0 {
+		return tp, nil
+	}
+
+	var stats []*columnStats
+	var names []string
+	for _, c := range t.Columns {
+		kind, ok := profileKind(c)
+		if !ok {
+			continue
+		}
+		stats = append(stats, &columnStats{col: c, kind: kind, counts: make(map[string]int)})
+		names = append(names, d.QuoteIdent(c.Name))
+	}
+	if len(stats) == 0 {
+		return tp, nil
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(names, ", "), d.QuoteIdent(t.Name))
+	rows, err := db.Query(d.GetLimitRowQuery(query, opts.SampleRows))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	raw := make([]interface{}, len(stats))
+	ptrs := make([]interface{}, len(stats))
+	for i := range raw {
+		ptrs[i] = &raw[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		tp.Sampled++
+		for i, s := range stats {
+			s.add(raw[i])
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	isFK := make(map[string]*schema.ForeignKey)
+	for _, fk := range t.ForeignKeys {
+		isFK[fk.Column] = fk
+	}
+	for _, s := range stats {
+		cp := s.profile(tp.Sampled, opts, isFK[s.col.Name] == nil)
+		tp.Columns[s.col.Name] = cp
+		if fk := isFK[s.col.Name]; fk != nil && len(s.counts) > 0 {
+			children := make([]float64, 0, len(s.counts))
+			for _, n := range s.counts {
+				children = append(children, float64(n))
+			}
+			if tp.FanOut == nil {
+				tp.FanOut = make(map[string]*FanOut)
+			}
+			tp.FanOut[s.col.Name] = &FanOut{RefTable: fk.RefTable, Children: newHistogram(children, opts.Buckets, true)}
+		}
+	}
+	return tp, nil
+}
+
+// profileKind decides which statistics a column gets; binary, spatial, JSON and
+// array columns are skipped.
+func profileKind(c *schema.Column) (string, bool) {
+	t := strings.ToLower(c.DataType)
+	switch {
+	case c.IsGenerated || c.ElementType != "":
+		return "", false
+	case strings.Contains(t, "binary") || strings.Contains(t, "blob") || strings.Contains(t, "bytea") ||
+		strings.Contains(t, "json") || strings.Contains(t, "xml") || strings.Contains(t, "geo") ||
+		strings.Contains(t, "point") || strings.Contains(t, "polygon") || strings.Contains(t, "image"):
+		return "", false
+	case isDateType(c.DataType):
+		return "date", true
+	case strings.Contains(t, "int") || strings.Contains(t, "decimal") || strings.Contains(t, "numeric") ||
+		strings.Contains(t, "float") || strings.Contains(t, "double") || strings.Contains(t, "real") ||
+		strings.Contains(t, "money") || t == "number":
+		return "number", true
+	case strings.Contains(t, "char") || strings.Contains(t, "text") || strings.Contains(t, "string") || strings.Contains(t, "clob"):
+		return "string", true
+	}
+	return "", true
+}
+
+func (s *columnStats) add(v interface{}) {
+	if v == nil {
+		s.nulls++
+		return
+	}
+	key := uniqueKeyOf(v)
+	if _, ok := s.counts[key]; ok || len(s.counts) < maxTrackedDistinct {
+		s.counts[key]++
+	}
+	switch s.kind {
+	case "number":
+		if f, err := strconv.ParseFloat(strings.TrimSpace(key), 64); err == nil {
+			s.values = append(s.values, f)
+		}
+	case "date":
+		if t, ok := parseTimeValue(v); ok {
+			wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+			s.values = append(s.values, float64(wall.Unix()))
+		}
+	case "string":
+		s.values = append(s.values, float64(len([]rune(key))))
+	}
+}
+
+// profile turns the accumulated sample into statistics. Frequent values are kept only
+// for non-key, non-PII, low-cardinality columns (status codes, not free text), and only
+// when at least MinCount rows share them.
+func (s *columnStats) profile(sampled int, opts ProfileOptions, keepValues bool) *ColumnProfile {
+	cp := &ColumnProfile{Distinct: len(s.counts)}
+	if sampled > 0 {
+		cp.NullRatio = float64(s.nulls) / float64(sampled)
+	}
+	switch s.kind {
+	case "number":
+		integer := strings.Contains(strings.ToLower(s.col.DataType), "int")
+		cp.Numbers = newHistogram(s.values, opts.Buckets, integer)
+	case "date":
+		cp.Dates = newHistogram(s.values, opts.Buckets, true)
+	case "string":
+		cp.Lengths = newHistogram(s.values, opts.Buckets, true)
+	}
+
+	nonNull := sampled - s.nulls
+	if !keepValues || s.kind == "date" || s.col.IsPK || s.col.IsUnique || isPIIColumn(s.col) || nonNull == 0 || opts.TopK <= 0 {
+		return cp
+	}
+	// Repeated prose (a canned reply, a pasted address) can still be personal
+	if (s.kind == "string" && isFreeText(s.col)) || (opts.MaxDistinct > 0 && len(s.counts) > opts.MaxDistinct) {
+		return cp
+	}
+	type freq struct {
+		value string
+		n     int
+	}
+	var fs []freq
+	for v, n := range s.counts {
+		if n >= opts.MinCount {
+			fs = append(fs, freq{v, n})
+		}
+	}
+	sort.Slice(fs, func(i, j int) bool {
+		if fs[i].n != fs[j].n {
+			return fs[i].n > fs[j].n
+		}
+		return fs[i].value < fs[j].value
+	})
+	if len(fs) > opts.TopK {
+		fs = fs[:opts.TopK]
+	}
+	for _, f := range fs {
+		cp.Top = append(cp.Top, TopValue{Value: f.value, Ratio: float64(f.n) / float64(nonNull)})
+	}
+	return cp
+}
@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"testing"
+
+	"db-pump/internal/schema"
+)
+
+// sampleStats feeds values into the statistics of one column as profileTable does.
+func sampleStats(col *schema.Column, values map[string]int) *columnStats {
+	kind, _ := profileKind(col)
+	s := &columnStats{col: col, kind: kind, counts: make(map[string]int)}
+	for v, n := range values {
+		for i := 0; i < n; i++ {
+			s.add(v)
+		}
+	}
+	return s
+}
+
+func TestColumnStats_ProfileKeepsNoKeyOrPIIValues(t *testing.T) {
+	opts := DefaultProfileOptions()
+	values := map[string]int{"A": 50, "B": 50}
+	col := func(name string) *schema.Column {
+		return &schema.Column{Name: name, DataType: "varchar", Length: 50, Meaning: schema.AnalyzeMeaning(name, "")}
+	}
+	pk, unique := col("code"), col("sku")
+	pk.IsPK, unique.IsUnique = true, true
+
+	for _, tc := range []struct {
+		col  *schema.Column
+		isFK bool
+	}{
+		{pk, false},
+		{unique, false},
+		{col("customer_code"), true},
+		{col("email"), false},
+		{col("phone"), false},
+		{col("last_name"), false},
+	} {
+		if cp := sampleStats(tc.col, values).profile(100, opts, !tc.isFK); len(cp.Top) > 0 {
+			t.Errorf("%s: literal values kept: %v", tc.col.Name, cp.Top)
+		}
+	}
+
+	if cp := sampleStats(col("status"), values).profile(100, opts, true); len(cp.Top) != 2 {
+		t.Errorf("status: got %v, want both frequent values", cp.Top)
+	}
+}
+
+func TestColumnStats_ProfileHonoursMinCount(t *testing.T) {
+	opts := DefaultProfileOptions()
+	opts.MinCount = 5
+	col := &schema.Column{Name: "status", DataType: "varchar", Length: 10}
+	cp := sampleStats(col, map[string]int{"PAID": 10, "RARE": 4}).profile(14, opts, true)
+	if len(cp.Top) != 1 || cp.Top[0].Value != "PAID" {
+		t.Fatalf("got %v, want only PAID (RARE is shared by fewer than %d rows)", cp.Top, opts.MinCount)
+	}
+	if r := cp.Top[0].Ratio; r < 0.71 || r > 0.72 {
+		t.Errorf("PAID ratio = %v, want 10/14", r)
+	}
+}
+
+func TestColumnStats_ProfileKeepsValuesOfCodeColumnsOnly(t *testing.T) {
+	opts := DefaultProfileOptions()
+	opts.MaxDistinct = 3
+	// 자유 텍스트는 같은 문장이 여러 번 나와도 저장하지 않음
+	memo := &schema.Column{Name: "memo", DataType: "varchar", Length: 200, Meaning: schema.AnalyzeMeaning("memo", "")}
+	if cp := sampleStats(memo, map[string]int{"배송 전 연락 바랍니다": 50}).profile(50, opts, true); len(cp.Top) > 0 {
+		t.Errorf("memo: literal values kept: %v", cp.Top)
+	}
+
+	// 고유값이 많으면 코드 컬럼이 아니다
+	tag := &schema.Column{Name: "tag", DataType: "varchar", Length: 20}
+	many := map[string]int{"a": 10, "b": 10, "c": 10, "d": 10}
+	if cp := sampleStats(tag, many).profile(40, opts, true); len(cp.Top) > 0 {
+		t.Errorf("tag with 4 distinct values: literal values kept: %v", cp.Top)
+	}
+	delete(many, "d")
+	if cp := sampleStats(tag, many).profile(30, opts, true); len(cp.Top) != 3 {
+		t.Errorf("tag with 3 distinct values: got %v", cp.Top)
+	}
+}
+
+func TestProfileValue_FollowsProfile(t *testing.T) {
+	defer func() { activeProfile = nil }()
+	activeProfile = &Profile{Tables: map[string]*TableProfile{
+		"orders": {Columns: map[string]*ColumnProfile{
+			"status":   {Top: []TopValue{{Value: "PAID", Ratio: 1}}},
+			"memo":     {NullRatio: 1},
+			"qty":      {Numbers: &Histogram{Min: 3, Max: 3, Integer: true, Counts: []int{1}}},
+			"sku":      {Top: []TopValue{{Value: "X", Ratio: 1}}},
+			"order_id": {Top: []TopValue{{Value: "1", Ratio: 1}}},
+		}},
+	}}
+	status := &schema.Column{Name: "status", DataType: "varchar", Length: 10}
+	memo := &schema.Column{Name: "memo", DataType: "varchar", Length: 100, IsNullable: true}
+	qty := &schema.Column{Name: "qty", DataType: "int"}
+	sku := &schema.Column{Name: "sku", DataType: "varchar", Length: 10, IsUnique: true}
+	id := &schema.Column{Name: "order_id", DataType: "int", IsPK: true}
+	orders := &schema.Table{Name: "orders", Columns: []*schema.Column{id, status, memo, qty, sku}}
+
+	for i := 0; i < 50; i++ {
+		if v, ok := profileValue(status, orders); !ok || v != "PAID" {
+			t.Fatalf("status: got %v, %v", v, ok)
+		}
+		if v, ok := profileValue(memo, orders); !ok || v != nil {
+			t.Fatalf("memo: got %v, %v, want NULL", v, ok)
+		}
+		if v, ok := profileValue(qty, orders); !ok || v != int64(3) {
+			t.Fatalf("qty: got %v, %v", v, ok)
+		}
+	}
+	// 키 컬럼은 프로파일 값을 쓰지 않음
+	if v, ok := profileValue(sku, orders); ok && v == "X" {
+		t.Error("UNIQUE column took a frequent value")
+	}
+	if _, ok := profileValue(id, orders); ok {
+		t.Error("PK column took a profile value")
+	}
+}
+
+func TestFanOutIndex_FollowsProfile(t *testing.T) {
+	defer func() { LoadProfile("") }()
+	LoadProfile("")
+	fk := &schema.ForeignKey{Column: "customer_id", RefTable: "customer", RefColumn: "customer_id"}
+	orders := &schema.Table{Name: "orders", ForeignKeys: []*schema.ForeignKey{fk}}
+
+	if _, ok := fanOutIndex(orders, fk, 100); ok {
+		t.Fatal("fan-out used without a profile")
+	}
+
+	// 부모의 절반은 자식이 없음
+	activeProfile = &Profile{Tables: map[string]*TableProfile{
+		"orders": {FanOut: map[string]*FanOut{"customer_id": {
+			RefTable: "customer", ZeroRatio: 0.5, Children: &Histogram{Min: 4, Max: 4, Integer: true, Counts: []int{1}},
+		}}},
+	}}
+	used := make(map[int]bool)
+	for i := 0; i < 5000; i++ {
+		idx, ok := fanOutIndex(orders, fk, 100)
+		if !ok || idx < 0 || idx >= 100 {
+			t.Fatalf("got %d, %v", idx, ok)
+		}
+		used[idx] = true
+	}
+	if len(used) < 25 || len(used) > 75 {
+		t.Errorf("%d of 100 parents got children, want about half", len(used))
+	}
+}
+
+func TestGetSmartVal_NoFanOutForCompositeKeys(t *testing.T) {
+	defer func() { LoadProfile("") }()
+	LoadProfile("")
+	activeProfile = &Profile{Tables: map[string]*TableProfile{
+		"film_actor": {FanOut: map[string]*FanOut{"actor_id": {
+			RefTable: "actor", Children: &Histogram{Min: 1, Max: 9, Integer: true, Counts: []int{1, 0, 5}},
+		}}},
+	}}
+	actorID := &schema.Column{Name: "actor_id", DataType: "int", IsPK: true}
+	filmID := &schema.Column{Name: "film_id", DataType: "int", IsPK: true}
+	filmActor := &schema.Table{
+		Name:    "film_actor",
+		Columns: []*schema.Column{actorID, filmID},
+		ForeignKeys: []*schema.ForeignKey{
+			{Column: "actor_id", RefTable: "actor", RefColumn: "actor_id"},
+			{Column: "film_id", RefTable: "film", RefColumn: "film_id"},
+		},
+	}
+	pool := map[string][]interface{}{"actor": {int64(1), int64(2), int64(3), int64(4), int64(5)}}
+
+	// 복합 PK의 FK는 순차 선택을 유지해야 조합이 겹치지 않음
+	for index := 1; index <= 10; index++ {
+		v, _ := getSmartValWithIndex(actorID, filmActor, pool, index)
+		if want := pool["actor"][index%5]; v != want {
+			t.Fatalf("index %d: got %v, want %v", index, v, want)
+		}
+	}
+	if !inCompositeKey(filmActor, actorID) || inCompositeKey(&schema.Table{Columns: []*schema.Column{actorID}}, actorID) {
+		t.Error("inCompositeKey misjudged the PK")
+	}
+}
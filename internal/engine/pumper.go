@@ -9,22 +9,6 @@ import (
 	"time"
 )
 
-// getDataTypeMaxValue returns the maximum value for a given data type
-func getDataTypeMaxValue(dataType string) int {
-	switch strings.ToLower(dataType) {
-	case "tinyint":
-		return 255
-	case "smallint":
-		return 32767
-	case "mediumint":
-		return 8388607
-	case "int", "integer":
-		return 2147483647
-	default:
-		return 2147483647 // Default to int max
-	}
-}
-
 // calculateMaxInsertCount calculates the maximum number of rows that can be inserted
 // based on IDENTITY column data type constraints
 func calculateMaxInsertCount(table *schema.Table, requestedCount int) int {
@@ -33,9 +17,12 @@ func calculateMaxInsertCount(table *schema.Table, requestedCount int) int {
 	// Check for IDENTITY columns with limited data types
 	for _, c := range table.Columns {
 		if c.IsAutoInc {
-			typeMax := getDataTypeMaxValue(c.DataType)
-			if typeMax < maxCount {
-				maxCount = typeMax
+			_, typeMax := intTypeRange(c.DataType, c.Unsigned)
+			if limit := digitLimit(c); limit > 0 && limit < typeMax {
+				typeMax = limit
+			}
+			if typeMax < int64(maxCount) {
+				maxCount = int(typeMax)
 				fmt.Printf("[LIMIT] Table %s: IDENTITY column %s (%s) limits max rows to %d\n",
 					table.Name, c.Name, c.DataType, typeMax)
 			}
@@ -48,23 +35,20 @@ func calculateMaxInsertCount(table *schema.Table, requestedCount int) int {
 func Pump(db *sql.DB, d dialect.Dialect, tables []*schema.Table, count int, onProgress func()) ([]schema.PumpResult, error) {
 	var results []schema.PumpResult
 	fkPool := make(map[string][]interface{})
+	parentTimes = make(map[string]map[string]time.Time)
 
 	for _, table := range tables {
 		// 기존 데이터 건수 확인
 		var initialCount int
-		db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", table.Name)).Scan(&initialCount)
+		db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", d.QuoteIdent(table.Name))).Scan(&initialCount)
 
-		// 데이터 타입 제약에 따른 최대 삽입 건수 계산
-		adjustedCount := calculateMaxInsertCount(table, count)
+		// 데이터 타입 제약에 따른 최대 삽입 건수 계산 (settings.profile.scale 이면 프로파일 행 수 기준)
+		tableCount := profiledCount(table.Name, count)
+		adjustedCount := calculateMaxInsertCount(table, tableCount)
 
-		// Check for identity column
+		// Identity columns are left to the database (insertColumns skips them), so no IDENTITY_INSERT
+		insertCols := insertColumns(table)
 		hasIdentity := false
-		for _, c := range table.Columns {
-			if c.IsAutoInc {
-				hasIdentity = true
-				break
-			}
-		}
 
 		// UI 진행바와 겹치지 않게 내부적으로만 처리
 		tx, _ := db.Begin()
@@ -72,40 +56,41 @@ func Pump(db *sql.DB, d dialect.Dialect, tables []*schema.Table, count int, onPr
 			fmt.Printf("Warning: BeforeTable hook failed for %s: %v\n", table.Name, err)
 		}
 
-		var insertCols []*schema.Column
-		var colNames []string
-		for _, c := range table.Columns {
-			if !c.IsAutoInc {
-				insertCols = append(insertCols, c)
-				colNames = append(colNames, c.Name)
-			}
+		var colNames, colTypes []string
+		for _, c := range insertCols {
+			colNames = append(colNames, c.Name)
+			colTypes = append(colTypes, c.DataType)
 		}
 
-		query := d.InsertQuery(table.Name, colNames)
+		query := d.InsertQuery(table.Name, colNames, colTypes)
+		queryCache := map[string]string{"": query} // keyed by columns left to their DB DEFAULT
 		inserted := 0
 		attempts := 0
 
-		// Track used combinations for composite PK tables
-		usedCombinations := make(map[string]bool)
-		hasCompositePK := false
-		pkCount := 0
-		for _, c := range table.Columns {
-			if c.IsPK {
-				pkCount++
-			}
-		}
-		if pkCount > 1 {
-			hasCompositePK = true
+		// Track used combinations for column sets that are unique together
+		// (composite PK and multi-column UNIQUE keys such as (store_id, email))
+		tupleKeys := uniqueTupleKeys(table, insertCols)
+		usedCombinations := make([]valueSet, len(tupleKeys))
+		for i := range usedCombinations {
+			usedCombinations[i] = newValueSet(initialCount + adjustedCount)
 		}
 
 		// Track used values for UNIQUE columns
-		usedUniqueValues := make(map[string]map[interface{}]bool)
+		usedUniqueValues := make(map[string]valueSet)
 		for _, c := range insertCols {
 			if c.IsUnique {
-				usedUniqueValues[c.Name] = make(map[interface{}]bool)
+				usedUniqueValues[c.Name] = newValueSet(initialCount + adjustedCount)
 			}
 		}
 
+		// Existing rows count too: duplicates against them are dropped by INSERT IGNORE / ON CONFLICT
+		if initialCount > 0 && Settings.Unique.Preload {
+			if err := preloadUniqueValues(db, d, table.Name, insertCols, usedUniqueValues, tupleKeys, usedCombinations); err != nil {
+				fmt.Printf("Warning: failed to preload UNIQUE values for %s: %v\n", table.Name, err)
+			}
+		}
+		uniqueSeq := initialCount // suffix sequence for colliding UNIQUE strings
+
 		// 목표치 채우기 로직 (중복 시 재시도)
 		// adjustedCount를 사용하여 데이터 타입 제약 준수
 		for inserted < adjustedCount && attempts < adjustedCount*10 {
@@ -117,46 +102,61 @@ func Pump(db *sql.DB, d dialect.Dialect, tables []*schema.Table, count int, onPr
 				break
 			}
 
-			// Check for composite PK duplicates
-			if hasCompositePK {
-				// Build combination key from PK values
-				var pkValues []string
-				for i, c := range insertCols {
-					if c.IsPK {
-						pkValues = append(pkValues, fmt.Sprintf("%v", values[i]))
-					}
+			// Check for composite PK / composite UNIQUE duplicates
+			skipRow := false
+			combinationKeys := make([]string, len(tupleKeys))
+			for k, idxs := range tupleKeys {
+				// Build combination key from the key's column values
+				parts := make([]interface{}, len(idxs))
+				for j, i := range idxs {
+					parts[j] = values[i]
 				}
-				combinationKey := strings.Join(pkValues, "|")
-				if usedCombinations[combinationKey] {
+				combinationKeys[k] = tupleKeyOf(parts)
+				if usedCombinations[k].Has(combinationKeys[k]) {
 					// Skip this duplicate combination
-					continue
+					skipRow = true
+					break
 				}
-				usedCombinations[combinationKey] = true
+			}
+			if skipRow {
+				continue
 			}
 
 			// Check for UNIQUE column duplicates
-			skipRow := false
 			for i, c := range insertCols {
-				if c.IsUnique {
-					if usedUniqueValues[c.Name][values[i]] {
-						// Skip this row - UNIQUE value already used
-						skipRow = true
-						break
+				if !c.IsUnique || !usedUniqueValues[c.Name].Has(uniqueKeyOf(values[i])) {
+					continue
+				}
+				// Strings can be made unique with a sequence suffix instead of burning an attempt
+				if s, ok := values[i].(string); ok && isStringColumn(c) {
+					for tries := 0; tries < 5 && usedUniqueValues[c.Name].Has(uniqueKeyOf(values[i])); tries++ {
+						uniqueSeq++
+						values[i] = uniqueString(s, uniqueSeq, c.Length)
+					}
+					if !usedUniqueValues[c.Name].Has(uniqueKeyOf(values[i])) {
+						continue
 					}
 				}
+				// Skip this row - UNIQUE value already used
+				skipRow = true
+				break
 			}
 			if skipRow {
 				continue
 			}
 
-			// Mark UNIQUE values as used
+			// Mark UNIQUE values and combinations as used
 			for i, c := range insertCols {
 				if c.IsUnique {
-					usedUniqueValues[c.Name][values[i]] = true
+					usedUniqueValues[c.Name].Add(uniqueKeyOf(values[i]))
 				}
 			}
+			for k, key := range combinationKeys {
+				usedCombinations[k].Add(key)
+			}
 
-			_, err := tx.Exec(query, values...)
+			execQuery, execValues := withoutDefaults(d, table.Name, insertCols, values, queryCache)
+			_, err := tx.Exec(execQuery, execValues...)
 			if err == nil {
 				inserted++
 				if onProgress != nil {
@@ -164,16 +164,16 @@ func Pump(db *sql.DB, d dialect.Dialect, tables []*schema.Table, count int, onPr
 				}
 			} else if attempts <= 3 {
 				// Log first 3 errors
-				fmt.Printf("[DEBUG] Table %s attempt %d: %v\nQuery: %s\n", table.Name, attempts, err, query)
+				fmt.Printf("[DEBUG] Table %s attempt %d: %v\nQuery: %s\n", table.Name, attempts, err, execQuery)
 			}
 		}
 
-		d.AfterTable(tx, table.Name, hasIdentity) // SET IDENTITY_INSERT OFF
+		d.AfterTable(tx, table.Name, hasIdentity)
 		tx.Commit()
 
 		// 실제 들어간 개수 확인 (Verification)
 		var finalCount int
-		db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", table.Name)).Scan(&finalCount)
+		db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", d.QuoteIdent(table.Name))).Scan(&finalCount)
 		actual := finalCount - initialCount
 
 		status := "OK"
@@ -190,37 +190,139 @@ func Pump(db *sql.DB, d dialect.Dialect, tables []*schema.Table, count int, onPr
 
 		results = append(results, schema.PumpResult{
 			TableName: table.Name,
-			Target:    count, // 원래 요청한 건수 표시
+			Target:    tableCount, // 원래 요청한 건수 표시
 			Actual:    actual,
 			Status:    status,
 			ErrorMsg:  errMsg,
 		})
 
 		// FK 풀 갱신 (다음 자식 테이블을 위해)
-		updateFKPool(db, table, fkPool)
+		updateFKPool(db, d, table, fkPool)
 	}
 
 	return results, nil
 }
 
+// insertColumns returns the columns we write: identity and generated/computed
+// columns reject explicit values.
+func insertColumns(table *schema.Table) []*schema.Column {
+	var cols []*schema.Column
+	for _, c := range table.Columns {
+		if !c.IsAutoInc && !c.IsGenerated {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// uniqueTupleKeys returns, as indexes into insertCols, the column sets whose combined
+// values must be unique: the composite PK and every multi-column UNIQUE key.
+// Keys that include a column not inserted by us (identity, generated) can't collide and are skipped.
+func uniqueTupleKeys(table *schema.Table, insertCols []*schema.Column) [][]int {
+	pos := make(map[string]int)
+	for i, c := range insertCols {
+		pos[strings.ToUpper(c.Name)] = i
+	}
+
+	var pkCols []string
+	for _, c := range table.Columns {
+		if c.IsPK {
+			pkCols = append(pkCols, c.Name)
+		}
+	}
+	candidates := table.UniqueKeys
+	if len(pkCols) > 1 {
+		candidates = append([][]string{pkCols}, candidates...)
+	}
+
+	var keys [][]int
+	for _, cols := range candidates {
+		if len(cols) < 2 {
+			continue // single-column keys are tracked via Column.IsUnique
+		}
+		idxs := make([]int, 0, len(cols))
+		for _, name := range cols {
+			i, ok := pos[strings.ToUpper(name)]
+			if !ok {
+				idxs = nil
+				break
+			}
+			idxs = append(idxs, i)
+		}
+		if idxs != nil {
+			keys = append(keys, idxs)
+		}
+	}
+	return keys
+}
+
+// inCompositeKey reports whether a column is part of the composite PK or of a
+// multi-column UNIQUE key.
+func inCompositeKey(table *schema.Table, col *schema.Column) bool {
+	pkCols := 0
+	for _, c := range table.Columns {
+		if c.IsPK {
+			pkCols++
+		}
+	}
+	if col.IsPK && pkCols > 1 {
+		return true
+	}
+	for _, cols := range table.UniqueKeys {
+		if len(cols) < 2 {
+			continue
+		}
+		for _, name := range cols {
+			if strings.EqualFold(name, col.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func generateRow(table *schema.Table, cols []*schema.Column, fkPool map[string][]interface{}) ([]interface{}, bool) {
 	return generateRowWithIndex(table, cols, fkPool, 0)
 }
 
 func generateRowWithIndex(table *schema.Table, cols []*schema.Column, fkPool map[string][]interface{}, index int) ([]interface{}, bool) {
-	var values []interface{}
-	for _, col := range cols {
-		val, ok := getSmartValWithIndex(col, table, fkPool, index)
+	beginRow()
+	choosePartition(table, index)
+	values := make([]interface{}, len(cols))
+	for _, i := range generationOrder(table, cols) {
+		val, ok := getSmartValWithIndex(cols[i], table, fkPool, index)
 		if !ok {
 			// FK constraint cannot be satisfied
 			return nil, false
 		}
-		values = append(values, val)
+		values[i] = val
 	}
 	return values, true
 }
 
-func updateFKPool(db *sql.DB, table *schema.Table, fkPool map[string][]interface{}) {
+// generationOrder returns column indexes in the order values are generated:
+// FK columns first (their parent rows bound the row's dates), then the rest,
+// and last the date columns that depend on other dates of the row (updated_at, end_date).
+func generationOrder(table *schema.Table, cols []*schema.Column) []int {
+	isFK := make(map[string]bool)
+	for _, fk := range table.ForeignKeys {
+		isFK[fk.Column] = true
+	}
+	var fks, rest, dependent []int
+	for i, c := range cols {
+		switch {
+		case isFK[c.Name]:
+			fks = append(fks, i)
+		case isTemporalDependent(c, table.Name):
+			dependent = append(dependent, i)
+		default:
+			rest = append(rest, i)
+		}
+	}
+	return append(append(fks, rest...), dependent...)
+}
+
+func updateFKPool(db *sql.DB, d dialect.Dialect, table *schema.Table, fkPool map[string][]interface{}) {
 	var pk string
 	for _, c := range table.Columns {
 		if c.IsPK {
@@ -233,7 +335,7 @@ func updateFKPool(db *sql.DB, table *schema.Table, fkPool map[string][]interface
 	}
 
 	// PK 값 수집 (MSSQL/Postgres 호환)
-	query := fmt.Sprintf("SELECT %s FROM %s", pk, table.Name)
+	query := fmt.Sprintf("SELECT %s FROM %s", d.QuoteIdent(pk), d.QuoteIdent(table.Name))
 	rows, err := db.Query(query)
 	if err != nil {
 		return
@@ -246,15 +348,18 @@ func updateFKPool(db *sql.DB, table *schema.Table, fkPool map[string][]interface
 			fkPool[table.Name] = append(fkPool[table.Name], id)
 		}
 	}
+
+	// 자식 테이블의 날짜가 부모 행 생성 시점보다 앞서지 않도록
+	loadParentTimes(db, d, table, pk)
 }
 
 // VerifyInjection checks the actual row counts after pumping and returns results.
-func VerifyInjection(db *sql.DB, results []schema.PumpResult) []schema.PumpResult {
+func VerifyInjection(db *sql.DB, d dialect.Dialect, results []schema.PumpResult) []schema.PumpResult {
 	var verifiedResults []schema.PumpResult
 	for _, res := range results {
 		var currentCount int
 		// Check current count again
-		err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", res.TableName)).Scan(&currentCount)
+		err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", d.QuoteIdent(res.TableName))).Scan(&currentCount)
 
 		status := "OK"
 		if err != nil {
@@ -283,10 +388,17 @@ func getSmartValWithIndex(col *schema.Column, t *schema.Table, pool map[string][
 		if fk.Column == col.Name {
 			if vals, ok := pool[fk.RefTable]; ok && len(vals) > 0 {
 				// For UNIQUE FK columns, always use sequential selection to avoid duplicates
+				v := vals[time.Now().UnixNano()%int64(len(vals))]
 				if col.IsUnique || index > 0 {
-					return vals[index%len(vals)], true
+					v = vals[index%len(vals)]
+				}
+				// 프로파일의 부모당 자식 수 분포를 따름
+				// (not for keys: junction tables need distinct parent combinations, which index gives)
+				if i, ok := fanOutIndex(t, fk, len(vals)); ok && !col.IsUnique && !inCompositeKey(t, col) {
+					v = vals[i]
 				}
-				return vals[time.Now().UnixNano()%int64(len(vals))], true
+				noteParentRow(fk.RefTable, v)
+				return v, true
 			}
 			// FK pool is empty - likely circular dependency
 			// If nullable, return NULL
@@ -307,5 +419,43 @@ func getSmartValWithIndex(col *schema.Column, t *schema.Table, pool map[string][
 			return 1, true
 		}
 	}
+	if shouldUseDefault(col, t.Name) {
+		return useDBDefault, true
+	}
+	if v, ok := profileValue(col, t); ok {
+		return v, true
+	}
 	return GenerateValue(col, t.Name), true
 }
+
+// withoutDefaults removes the columns that take their DB DEFAULT in this row and
+// returns the matching INSERT. Queries are cached per set of omitted columns.
+// When every column is defaulted the first one gets a generated value instead:
+// "INSERT INTO t () VALUES ()" is MySQL only, and DEFAULT VALUES isn't MySQL.
+func withoutDefaults(d dialect.Dialect, table string, cols []*schema.Column, values []interface{}, cache map[string]string) (string, []interface{}) {
+	var names, types []string
+	var kept []interface{}
+	var omitted []string
+	for i, c := range cols {
+		if isDBDefault(values[i]) {
+			omitted = append(omitted, c.Name)
+			continue
+		}
+		names = append(names, c.Name)
+		types = append(types, c.DataType)
+		kept = append(kept, values[i])
+	}
+	if len(names) == 0 && len(cols) > 0 {
+		omitted = omitted[1:]
+		names, types = []string{cols[0].Name}, []string{cols[0].DataType}
+		kept = []interface{}{GenerateValue(cols[0], table)}
+	}
+
+	key := strings.Join(omitted, "|")
+	if q, ok := cache[key]; ok {
+		return q, kept
+	}
+	q := d.InsertQuery(table, names, types)
+	cache[key] = q
+	return q, kept
+}
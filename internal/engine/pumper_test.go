@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"db-pump/internal/schema"
+)
+
+func TestUniqueTupleKeys(t *testing.T) {
+	id := &schema.Column{Name: "id", IsAutoInc: true}
+	filmID := &schema.Column{Name: "film_id", IsPK: true}
+	actorID := &schema.Column{Name: "actor_id", IsPK: true}
+	role := &schema.Column{Name: "role"}
+	email := &schema.Column{Name: "email", IsUnique: true}
+	table := &schema.Table{
+		Name:    "film_actor",
+		Columns: []*schema.Column{id, filmID, actorID, role, email},
+		UniqueKeys: [][]string{
+			{"email"},            // 단일 컬럼 키는 IsUnique로 처리
+			{"ACTOR_ID", "role"}, // 대소문자 무시
+			{"id", "role"},       // INSERT에 없는 identity 컬럼이 든 키는 충돌할 수 없음
+		},
+	}
+	insertCols := []*schema.Column{filmID, actorID, role, email}
+
+	keys := uniqueTupleKeys(table, insertCols)
+	if want := [][]int{{0, 1}, {1, 2}}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("got %v, want %v", keys, want)
+	}
+
+	// 같은 조합은 두 번째부터 걸러짐
+	used := make(exactSet)
+	rows := [][]interface{}{{1, 10, "lead", "a@x"}, {1, 11, "lead", "b@x"}, {1, 10, "extra", "c@x"}}
+	var dup []int
+	for r, values := range rows {
+		parts := make([]interface{}, len(keys[0]))
+		for j, i := range keys[0] {
+			parts[j] = values[i]
+		}
+		if k := tupleKeyOf(parts); used.Has(k) {
+			dup = append(dup, r)
+		} else {
+			used.Add(k)
+		}
+	}
+	if !reflect.DeepEqual(dup, []int{2}) {
+		t.Errorf("duplicate (film_id, actor_id) rows: got %v, want [2]", dup)
+	}
+}
+
+func TestInsertColumns_SkipsIdentityAndGenerated(t *testing.T) {
+	table := &schema.Table{Name: "orders", Columns: []*schema.Column{
+		{Name: "order_id", IsPK: true, IsAutoInc: true},
+		{Name: "price"},
+		{Name: "qty"},
+		{Name: "total", IsGenerated: true}, // GENERATED ALWAYS AS (price * qty)
+	}}
+	var names []string
+	for _, c := range insertColumns(table) {
+		names = append(names, c.Name)
+	}
+	if want := []string{"price", "qty"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"math"
+	"strings"
+
+	"db-pump/internal/schema"
+)
+
+// Integer range modes (settings.integer_range)
+const (
+	RangeRealistic = "realistic" // small, human-looking values (default)
+	RangeFull      = "full"      // whole type range including boundaries, for overflow testing
+)
+
+// realisticIntMax is the upper bound used in realistic mode for types wider than smallint.
+const realisticIntMax = 50000
+
+// intTypeRange returns the storable range of an integer type.
+// Unknown types are treated as a 32-bit INT. Unsigned BIGINT is capped at MaxInt64.
+func intTypeRange(dataType string, unsigned bool) (int64, int64) {
+	t := strings.ToLower(dataType)
+	switch {
+	case strings.Contains(t, "tinyint"):
+		if unsigned {
+			return 0, math.MaxUint8
+		}
+		return math.MinInt8, math.MaxInt8
+	case strings.Contains(t, "smallint"):
+		if unsigned {
+			return 0, math.MaxUint16
+		}
+		return math.MinInt16, math.MaxInt16
+	case strings.Contains(t, "mediumint"):
+		if unsigned {
+			return 0, 1<<24 - 1
+		}
+		return -1 << 23, 1<<23 - 1
+	case strings.Contains(t, "bigint"):
+		if unsigned {
+			return 0, math.MaxInt64
+		}
+		return math.MinInt64, math.MaxInt64
+	default: // int, integer, int4
+		if unsigned {
+			return 0, math.MaxUint32
+		}
+		return math.MinInt32, math.MaxInt32
+	}
+}
+
+// digitLimit returns 10^n - 1 for integer columns declared with a digit count
+// (Oracle NUMBER(5), MSSQL/MySQL precision), or 0 if none applies.
+func digitLimit(col *schema.Column) int64 {
+	n := col.Precision
+	if n == 0 {
+		n = col.Length
+	}
+	if n <= 0 || n >= 19 {
+		return 0
+	}
+	return int64(math.Pow10(n)) - 1
+}
+
+// intRange returns the generation range for an integer column according to Settings.IntegerRange.
+func intRange(col *schema.Column) (int64, int64) {
+	lo, hi := intTypeRange(col.DataType, col.Unsigned)
+	if limit := digitLimit(col); limit > 0 {
+		hi = min(hi, limit)
+		lo = max(lo, -limit)
+	}
+
+	if Settings.IntegerRange != RangeFull {
+		realisticMax := int64(realisticIntMax)
+		if strings.Contains(strings.ToLower(col.DataType), "smallint") {
+			realisticMax = 30000
+		}
+		realisticMin := int64(1)
+		if strings.Contains(strings.ToLower(col.DataType), "tinyint") {
+			realisticMin = 0
+		}
+		lo, hi = max(lo, realisticMin), min(hi, realisticMax)
+		if hi < lo {
+			hi = lo
+		}
+	}
+
+	if col.Check != nil && (col.Check.Min != nil || col.Check.Max != nil) {
+		fLo, fHi := checkBounds(col, float64(lo), float64(hi), 1)
+		lo, hi = int64(fLo), int64(fHi)
+	}
+	return lo, hi
+}
+
+// generateInt draws a value from intRange. In full-range mode the boundaries
+// themselves are picked now and then so overflow edges are actually exercised.
+func generateInt(col *schema.Column) int64 {
+	lo, hi := intRange(col)
+	if Settings.IntegerRange == RangeFull && seededRand.Intn(10) == 0 {
+		if seededRand.Intn(2) == 0 {
+			return lo
+		}
+		return hi
+	}
+	return randInt64(lo, hi)
+}
+
+// randInt64 returns a uniform value in [lo, hi], safe for the full int64 span.
+func randInt64(lo, hi int64) int64 {
+	if hi <= lo {
+		return lo
+	}
+	span := uint64(hi) - uint64(lo)
+	if span == math.MaxUint64 {
+		return int64(seededRand.Uint64())
+	}
+	return lo + int64(seededRand.Uint64()%(span+1))
+}
@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"time"
+
+	"db-pump/internal/schema"
+)
+
+// rowState carries values shared by the columns of the row currently being
+// generated, so that related columns agree with each other (e.g. a latitude
+// column, a longitude column and a geometry column describe the same place;
+// city, district, address and postal code columns describe the same address).
+// Generation is single-threaded, so a package-level state reset per row is enough.
+type rowState struct {
+	geo *geoPoint
+
+	times     map[string]time.Time // date columns generated so far, by lowercased name
+	start     time.Time            // the row's creation/start moment
+	latest    time.Time            // latest non-end date so far; end columns come after it
+	notBefore time.Time            // creation time of the newest parent row referenced via FK
+
+	partition    *schema.Partition // partition the row is aimed at (nil if the table isn't partitioned)
+	partitionKey string
+
+	address *rowAddress // city / district / address / postal code columns share one address
+	persona *persona    // name / email / username / gender / birth date columns describe one person
+}
+
+var currentRow = &rowState{}
+
+// beginRow discards the shared state of the previous row.
+func beginRow() {
+	currentRow = &rowState{}
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/schema"
+)
+
+// SpatialConfig is the bounding box used for coordinates and geometries (WGS84 degrees).
+type SpatialConfig struct {
+	MinLat float64 `mapstructure:"min_lat"`
+	MaxLat float64 `mapstructure:"max_lat"`
+	MinLng float64 `mapstructure:"min_lng"`
+	MaxLng float64 `mapstructure:"max_lng"`
+}
+
+type geoPoint struct {
+	Lat, Lng float64
+}
+
+// rowPoint returns the location shared by all spatial columns of the current row.
+func rowPoint() *geoPoint {
+	if currentRow.geo == nil {
+		b := Settings.Spatial
+		currentRow.geo = &geoPoint{
+			Lat: b.MinLat + seededRand.Float64()*(b.MaxLat-b.MinLat),
+			Lng: b.MinLng + seededRand.Float64()*(b.MaxLng-b.MinLng),
+		}
+	}
+	return currentRow.geo
+}
+
+// generateSpatial handles geometry columns (returned as WKT; the dialect wraps the
+// placeholder in ST_GeomFromText / geography::STGeomFromText / SDO_GEOMETRY) and
+// latitude/longitude columns. ok is false for any other column.
+func generateSpatial(col *schema.Column) (interface{}, bool) {
+	dataType := strings.ToLower(col.DataType)
+	if dialect.IsSpatialType(dataType) {
+		p := rowPoint()
+		if strings.Contains(dataType, "polygon") {
+			return polygonWKT(p), true
+		}
+		return fmt.Sprintf("POINT(%.6f %.6f)", p.Lng, p.Lat), true // WKT is (x y) = (lng lat)
+	}
+
+	var coord float64
+	switch {
+	case isLatitudeColumn(col):
+		coord = rowPoint().Lat
+	case isLongitudeColumn(col):
+		coord = rowPoint().Lng
+	default:
+		return nil, false
+	}
+
+	scale := col.Scale
+	if scale <= 0 {
+		scale = 6
+	}
+	pow := math.Pow10(scale)
+	coord = math.Round(coord*pow) / pow
+
+	if strings.Contains(dataType, "char") || strings.Contains(dataType, "text") || strings.Contains(dataType, "string") {
+		return truncate(fmt.Sprintf("%.*f", scale, coord), col.Length), true
+	}
+	if strings.Contains(dataType, "decimal") || strings.Contains(dataType, "numeric") ||
+		strings.Contains(dataType, "float") || strings.Contains(dataType, "double") || strings.Contains(dataType, "real") {
+		return coord, true
+	}
+	return nil, false // e.g. an integer "lat_cnt" column: leave it to the numeric branch
+}
+
+func isLatitudeColumn(col *schema.Column) bool {
+	n := strings.ToLower(col.Name)
+	return strings.Contains(col.Meaning, "latitude") || n == "lat" || strings.HasSuffix(n, "_lat")
+}
+
+func isLongitudeColumn(col *schema.Column) bool {
+	n := strings.ToLower(col.Name)
+	return strings.Contains(col.Meaning, "longitude") || n == "lng" || n == "lon" ||
+		strings.HasSuffix(n, "_lng") || strings.HasSuffix(n, "_lon")
+}
+
+// polygonWKT returns a small counter-clockwise square around p
+// (exterior rings must be CCW for SQL Server geography).
+func polygonWKT(p *geoPoint) string {
+	d := 0.001 + seededRand.Float64()*0.01
+	x0, y0, x1, y1 := p.Lng-d, p.Lat-d, p.Lng+d, p.Lat+d
+	return fmt.Sprintf("POLYGON((%.6f %.6f, %.6f %.6f, %.6f %.6f, %.6f %.6f, %.6f %.6f))",
+		x0, y0, x1, y0, x1, y1, x0, y1, x0, y0)
+}
@@ -0,0 +1,449 @@
+package engine
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/schema"
+)
+
+// SubsetRoot selects the rows a subset starts from: the rows of Table matching Where
+// (SQL condition, "" = all), of which Percent are kept (0 or 100 = all).
+type SubsetRoot struct {
+	Table   string
+	Where   string
+	Percent float64
+}
+
+// RowSource reads rows for subsetting. Rows hold the table's non-generated columns
+// in column order, as Copy reads them.
+type RowSource interface {
+	Scan(t *schema.Table, where string, fn func(values []interface{})) error
+	Lookup(t *schema.Table, column string, values []interface{}, fn func(values []interface{})) error
+}
+
+// Subset is a referentially closed set of rows: every selected row's FK parents are
+// selected too.
+type Subset struct {
+	order  []*schema.Table
+	tables map[string]*subsetTable
+}
+
+type subsetTable struct {
+	t        *schema.Table
+	cols     []*schema.Column
+	keyCols  []int // PK columns; every column when there is no PK
+	hasPK    bool
+	rows     map[string][]interface{}   // selected rows: key → key column values
+	expanded map[string]bool            // rows whose children were followed
+	values   map[string]map[string]bool // referenced column → selected values
+}
+
+// pendingLookup collects values to look up in one column of a table.
+type pendingLookup struct {
+	table  *subsetTable
+	column string
+	expand bool // rows found are children of an expanded row: follow their children too
+	values []interface{}
+}
+
+// subsetBatch is the number of values per "column IN (...)" lookup.
+const subsetBatch = 500
+
+// BuildSubset selects the root rows and walks the FK graph from them: FK parents of
+// every selected row are always added (a row can't exist without them), children of
+// root rows are added when children is set, and then their children in turn. Rows
+// reached only as parents don't pull in their other children, which would otherwise
+// drag in most of the database.
+func BuildSubset(src RowSource, all []*schema.Table, roots []SubsetRoot, children bool) (*Subset, error) {
+	s := &Subset{tables: make(map[string]*subsetTable)}
+	for _, t := range all {
+		st := &subsetTable{t: t, rows: make(map[string][]interface{}), expanded: make(map[string]bool), values: make(map[string]map[string]bool)}
+		for _, c := range t.Columns {
+			if c.IsGenerated {
+				continue
+			}
+			if c.IsPK {
+				st.keyCols = append(st.keyCols, len(st.cols))
+			}
+			st.cols = append(st.cols, c)
+		}
+		st.hasPK = len(st.keyCols) > 0
+		if !st.hasPK {
+			for i := range st.cols {
+				st.keyCols = append(st.keyCols, i)
+			}
+		}
+		s.order = append(s.order, t)
+		s.tables[strings.ToLower(t.Name)] = st
+	}
+
+	queue := make(map[string]*pendingLookup)
+	requested := make(map[string]map[string]bool) // lookups already queued, by table.column(+children)
+	enqueue := func(st *subsetTable, column string, expand bool, v interface{}) {
+		if v == nil {
+			return
+		}
+		id := fmt.Sprintf("%s.%s.%t", st.t.Name, column, expand)
+		k := uniqueKeyOf(v)
+		if requested[id] == nil {
+			requested[id] = make(map[string]bool)
+		}
+		if requested[id][k] || (!expand && st.values[strings.ToLower(column)][k]) {
+			return
+		}
+		requested[id][k] = true
+		p := queue[id]
+		if p == nil {
+			p = &pendingLookup{table: st, column: column, expand: expand}
+			queue[id] = p
+		}
+		p.values = append(p.values, v)
+	}
+
+	var add func(st *subsetTable, values []interface{}, expand bool)
+	add = func(st *subsetTable, values []interface{}, expand bool) {
+		k := st.key(values)
+		if _, ok := st.rows[k]; ok && (!expand || st.expanded[k]) {
+			return
+		}
+		st.rows[k] = st.keyValues(values)
+		for i, c := range st.cols {
+			if values[i] == nil {
+				continue
+			}
+			name := strings.ToLower(c.Name)
+			if st.values[name] == nil {
+				st.values[name] = make(map[string]bool)
+			}
+			st.values[name][uniqueKeyOf(values[i])] = true
+		}
+
+		// Parents: required
+		for _, fk := range st.t.ForeignKeys {
+			pt := s.tables[strings.ToLower(fk.RefTable)]
+			if i := st.index(fk.Column); pt != nil && i >= 0 {
+				enqueue(pt, fk.RefColumn, false, values[i])
+			}
+		}
+
+		// Children: only from rows selected for their own sake
+		if !expand || !children {
+			return
+		}
+		st.expanded[k] = true
+		for _, ct := range s.tables {
+			for _, fk := range ct.t.ForeignKeys {
+				if !strings.EqualFold(fk.RefTable, st.t.Name) {
+					continue
+				}
+				if i := st.index(fk.RefColumn); i >= 0 {
+					enqueue(ct, fk.Column, true, values[i])
+				}
+			}
+		}
+	}
+
+	for _, r := range roots {
+		st := s.tables[strings.ToLower(r.Table)]
+		if st == nil {
+			return nil, fmt.Errorf("unknown root table %q", r.Table)
+		}
+		err := src.Scan(st.t, r.Where, func(values []interface{}) {
+			if inSample(st.key(values), r.Percent) {
+				add(st, values, true)
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("select roots from %s: %w", r.Table, err)
+		}
+	}
+
+	for len(queue) > 0 {
+		for id, p := range queue {
+			delete(queue, id)
+			for start := 0; start < len(p.values); start += subsetBatch {
+				end := start + subsetBatch
+				if end > len(p.values) {
+					end = len(p.values)
+				}
+				err := src.Lookup(p.table.t, p.column, p.values[start:end], func(values []interface{}) {
+					add(p.table, values, p.expand)
+				})
+				if err != nil {
+					return nil, fmt.Errorf("look up %s.%s: %w", p.table.t.Name, p.column, err)
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+func (st *subsetTable) key(values []interface{}) string {
+	return tupleKeyOf(st.keyValues(values))
+}
+
+func (st *subsetTable) keyValues(values []interface{}) []interface{} {
+	parts := make([]interface{}, len(st.keyCols))
+	for j, i := range st.keyCols {
+		parts[j] = values[i]
+	}
+	return parts
+}
+
+func (st *subsetTable) index(column string) int {
+	for i, c := range st.cols {
+		if strings.EqualFold(c.Name, column) {
+			return i
+		}
+	}
+	return -1
+}
+
+// inSample keeps a stable percentage of rows, decided by the row key, so the same
+// subset is selected on every run.
+func inSample(key string, percent float64) bool {
+	if percent <= 0 || percent >= 100 {
+		return true
+	}
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return float64(h.Sum64()%1000000) < percent*10000
+}
+
+// Tables returns the tables with selected rows, in dependency order.
+func (s *Subset) Tables() []*schema.Table {
+	var out []*schema.Table
+	for _, t := range s.order {
+		if len(s.tables[strings.ToLower(t.Name)].rows) > 0 {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Count returns the number of selected rows of a table.
+func (s *Subset) Count(table string) int {
+	if st := s.tables[strings.ToLower(table)]; st != nil {
+		return len(st.rows)
+	}
+	return 0
+}
+
+// Keep reports whether a row read by Copy is part of the subset.
+func (s *Subset) Keep(t *schema.Table, cols []*schema.Column, values []interface{}) bool {
+	st := s.tables[strings.ToLower(t.Name)]
+	if st == nil {
+		return false
+	}
+	_, ok := st.rows[st.key(values)]
+	return ok
+}
+
+// Source returns a RowSource whose Scan reads only the selected rows of a table, with
+// batched "pk IN (...)" lookups (tables without a PK are scanned and filtered).
+// Rows of self-referencing tables come parents first, so Copy can insert them in order.
+func (s *Subset) Source(src RowSource) RowSource {
+	return &subsetSource{s: s, src: src}
+}
+
+type subsetSource struct {
+	s   *Subset
+	src RowSource
+}
+
+func (r *subsetSource) Scan(t *schema.Table, where string, fn func(values []interface{})) error {
+	st := r.s.tables[strings.ToLower(t.Name)]
+	if st == nil || len(st.rows) == 0 {
+		return nil
+	}
+	emit := fn
+	var buffered [][]interface{}
+	if st.selfReferencing() {
+		emit = func(values []interface{}) { buffered = append(buffered, values) }
+	}
+	keep := func(values []interface{}) {
+		if _, ok := st.rows[st.key(values)]; ok {
+			emit(values)
+		}
+	}
+
+	if !st.hasPK {
+		if err := r.src.Scan(t, where, keep); err != nil {
+			return err
+		}
+	} else {
+		// Composite keys are looked up by their first column and filtered by the full key
+		first := st.cols[st.keyCols[0]].Name
+		seen := make(map[string]bool)
+		var values []interface{}
+		for _, kv := range st.rows {
+			if k := uniqueKeyOf(kv[0]); !seen[k] {
+				seen[k] = true
+				values = append(values, kv[0])
+			}
+		}
+		for start := 0; start < len(values); start += subsetBatch {
+			end := start + subsetBatch
+			if end > len(values) {
+				end = len(values)
+			}
+			if err := r.src.Lookup(t, first, values[start:end], keep); err != nil {
+				return err
+			}
+		}
+	}
+
+	if buffered != nil {
+		st.parentsFirst(buffered, fn)
+	}
+	return nil
+}
+
+func (r *subsetSource) Lookup(t *schema.Table, column string, values []interface{}, fn func(values []interface{})) error {
+	return r.src.Lookup(t, column, values, func(row []interface{}) {
+		if r.s.Keep(t, nil, row) {
+			fn(row)
+		}
+	})
+}
+
+func (st *subsetTable) selfReferencing() bool {
+	for _, fk := range st.t.ForeignKeys {
+		if strings.EqualFold(fk.RefTable, st.t.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parentsFirst passes the rows of a self-referencing table to fn so that a row comes
+// after the row it references (manager before employee). Rows in a reference cycle
+// come last, in their original order.
+func (st *subsetTable) parentsFirst(rows [][]interface{}, fn func(values []interface{})) {
+	type ref struct{ col, ref int } // FK column → referenced column, as indexes into cols
+	var refs []ref
+	for _, fk := range st.t.ForeignKeys {
+		if strings.EqualFold(fk.RefTable, st.t.Name) {
+			if c, r := st.index(fk.Column), st.index(fk.RefColumn); c >= 0 && r >= 0 {
+				refs = append(refs, ref{c, r})
+			}
+		}
+	}
+
+	// Rows providing each referenced value, and rows waiting for it
+	refKey := func(col int, v interface{}) string { return strconv.Itoa(col) + "\x00" + uniqueKeyOf(v) }
+	provides := make(map[string]bool)
+	for _, row := range rows {
+		for _, f := range refs {
+			provides[refKey(f.ref, row[f.ref])] = true
+		}
+	}
+	waiting := make(map[string][]int)
+	pending := make([]int, len(rows))
+	var ready []int
+	for i, row := range rows {
+		for _, f := range refs {
+			if row[f.col] == nil || uniqueKeyOf(row[f.col]) == uniqueKeyOf(row[f.ref]) {
+				continue // no parent, or the row references itself
+			}
+			if k := refKey(f.ref, row[f.col]); provides[k] {
+				waiting[k] = append(waiting[k], i)
+				pending[i]++
+			}
+		}
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	done := make([]bool, len(rows))
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		done[i] = true
+		fn(rows[i])
+		for _, f := range refs {
+			k := refKey(f.ref, rows[i][f.ref])
+			for _, j := range waiting[k] {
+				if pending[j]--; pending[j] == 0 {
+					ready = append(ready, j)
+				}
+			}
+			delete(waiting, k)
+		}
+	}
+	for i, row := range rows {
+		if !done[i] {
+			fn(row)
+		}
+	}
+}
+
+// dbRowSource reads subset rows with SELECTs.
+type dbRowSource struct {
+	db *sql.DB
+	d  dialect.Dialect
+}
+
+// NewDBRowSource returns a RowSource reading from a database.
+func NewDBRowSource(db *sql.DB, d dialect.Dialect) RowSource {
+	return &dbRowSource{db: db, d: d}
+}
+
+func (r *dbRowSource) selectCols(t *schema.Table) ([]*schema.Column, string) {
+	var cols []*schema.Column
+	var quoted []string
+	for _, c := range t.Columns {
+		if !c.IsGenerated {
+			cols = append(cols, c)
+			quoted = append(quoted, r.d.QuoteIdent(c.Name))
+		}
+	}
+	return cols, fmt.Sprintf("SELECT %s FROM %s", strings.Join(quoted, ", "), r.d.QuoteIdent(t.Name))
+}
+
+func (r *dbRowSource) Scan(t *schema.Table, where string, fn func(values []interface{})) error {
+	cols, query := r.selectCols(t)
+	if where != "" {
+		query += " WHERE " + where
+	}
+	return r.query(cols, query, nil, fn)
+}
+
+func (r *dbRowSource) Lookup(t *schema.Table, column string, values []interface{}, fn func(values []interface{})) error {
+	cols, query := r.selectCols(t)
+	ph := make([]string, len(values))
+	for i := range values {
+		ph[i] = r.d.Placeholder(i)
+	}
+	query += fmt.Sprintf(" WHERE %s IN (%s)", r.d.QuoteIdent(column), strings.Join(ph, ", "))
+	return r.query(cols, query, values, fn)
+}
+
+func (r *dbRowSource) query(cols []*schema.Column, query string, args []interface{}, fn func(values []interface{})) error {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		raw := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range raw {
+			ptrs[i] = &raw[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		for i, c := range cols {
+			raw[i] = normalizeScanned(c, raw[i])
+		}
+		fn(raw)
+	}
+	return rows.Err()
+}
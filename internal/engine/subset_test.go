@@ -0,0 +1,236 @@
+package engine_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"db-pump/internal/dialect"
+	"db-pump/internal/engine"
+	"db-pump/internal/schema"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// memorySource는 테이블별 행을 메모리에 두고 RowSource를 흉내 낸다.
+// where 조건은 "column=value" 형식만 지원한다.
+type memorySource map[string][][]interface{}
+
+func (m memorySource) Scan(t *schema.Table, where string, fn func([]interface{})) error {
+	for _, row := range m[t.Name] {
+		if where == "" || matches(t, row, strings.SplitN(where, "=", 2)[0], []interface{}{strings.SplitN(where, "=", 2)[1]}) {
+			fn(row)
+		}
+	}
+	return nil
+}
+
+func (m memorySource) Lookup(t *schema.Table, column string, values []interface{}, fn func([]interface{})) error {
+	for _, row := range m[t.Name] {
+		if matches(t, row, column, values) {
+			fn(row)
+		}
+	}
+	return nil
+}
+
+func matches(t *schema.Table, row []interface{}, column string, values []interface{}) bool {
+	for i, c := range t.Columns {
+		if c.Name != column {
+			continue
+		}
+		for _, v := range values {
+			if fmt.Sprint(row[i]) == fmt.Sprint(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestBuildSubset_FollowsForeignKeys(t *testing.T) {
+	pk := func(name string) *schema.Column { return &schema.Column{Name: name, DataType: "int", IsPK: true} }
+	col := func(name string) *schema.Column { return &schema.Column{Name: name, DataType: "int", IsNullable: true} }
+	store := &schema.Table{Name: "store", Columns: []*schema.Column{pk("store_id")}}
+	film := &schema.Table{Name: "film", Columns: []*schema.Column{pk("film_id")}}
+	customer := &schema.Table{Name: "customer", Columns: []*schema.Column{pk("customer_id"), col("store_id")},
+		ForeignKeys: []*schema.ForeignKey{{Column: "store_id", RefTable: "store", RefColumn: "store_id"}}}
+	rental := &schema.Table{Name: "rental", Columns: []*schema.Column{pk("rental_id"), col("customer_id"), col("film_id")},
+		ForeignKeys: []*schema.ForeignKey{
+			{Column: "customer_id", RefTable: "customer", RefColumn: "customer_id"},
+			{Column: "film_id", RefTable: "film", RefColumn: "film_id"},
+		}}
+	tables := []*schema.Table{store, film, customer, rental}
+
+	src := memorySource{
+		"store":    {{1}, {2}},
+		"film":     {{10}, {11}, {12}},
+		"customer": {{100, 1}, {101, 2}, {102, 2}},
+		"rental":   {{1000, 100, 10}, {1001, 100, 11}, {1002, 101, 10}, {1003, 102, 12}},
+	}
+
+	// 고객 100과 그 대여 기록, 대여가 참조하는 영화와 고객의 매장
+	s, err := engine.BuildSubset(src, tables, []engine.SubsetRoot{{Table: "customer", Where: "customer_id=100"}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for table, want := range map[string]int{"store": 1, "film": 2, "customer": 1, "rental": 2} {
+		if got := s.Count(table); got != want {
+			t.Errorf("%s: %d rows, want %d", table, got, want)
+		}
+	}
+	// 부모로만 포함된 영화 10의 다른 대여(1002)는 따라가지 않는다
+	if s.Keep(rental, rental.Columns, []interface{}{1002, 101, 10}) {
+		t.Error("rental 1002 of another customer was included")
+	}
+	if names := len(s.Tables()); names != 4 {
+		t.Errorf("got %d tables, want 4", names)
+	}
+
+	// 자식 테이블을 따라가지 않으면 부모만 포함
+	s, _ = engine.BuildSubset(src, tables, []engine.SubsetRoot{{Table: "rental", Where: "rental_id=1003"}}, false)
+	for table, want := range map[string]int{"store": 1, "film": 1, "customer": 1, "rental": 1} {
+		if got := s.Count(table); got != want {
+			t.Errorf("children=false %s: %d rows, want %d", table, got, want)
+		}
+	}
+
+	// 비율 지정: 실행마다 같은 행이 약 30% 선택된다
+	many := memorySource{"store": {{1}}}
+	for i := 0; i < 1000; i++ {
+		many["customer"] = append(many["customer"], []interface{}{i, 1})
+	}
+	roots := []engine.SubsetRoot{{Table: "customer", Percent: 30}}
+	a, _ := engine.BuildSubset(many, tables, roots, false)
+	b, _ := engine.BuildSubset(many, tables, roots, false)
+	if n := a.Count("customer"); n < 250 || n > 350 || n != b.Count("customer") {
+		t.Errorf("30%% of 1000 customers: %d then %d rows", n, b.Count("customer"))
+	}
+}
+
+// countingSource는 전체 조회 없이 키 조회만 하는지 확인한다.
+type countingSource struct {
+	memorySource
+	scans, lookups int
+}
+
+func (c *countingSource) Scan(t *schema.Table, where string, fn func([]interface{})) error {
+	c.scans++
+	return c.memorySource.Scan(t, where, fn)
+}
+
+func (c *countingSource) Lookup(t *schema.Table, column string, values []interface{}, fn func([]interface{})) error {
+	c.lookups++
+	return c.memorySource.Lookup(t, column, values, fn)
+}
+
+func TestSubsetSource_ReadsSelectedRowsParentsFirst(t *testing.T) {
+	employee := &schema.Table{
+		Name: "employee",
+		Columns: []*schema.Column{
+			{Name: "employee_id", DataType: "int", IsPK: true},
+			{Name: "manager_id", DataType: "int", IsNullable: true},
+		},
+		ForeignKeys: []*schema.ForeignKey{{Column: "manager_id", RefTable: "employee", RefColumn: "employee_id"}},
+	}
+	// 스캔 순서로는 부하 직원이 관리자보다 먼저 나옴: 4 → 3 → 2 → 1(최상위)
+	src := &countingSource{memorySource: memorySource{
+		"employee": {{4, 3}, {9, nil}, {3, 2}, {2, 1}, {1, nil}},
+	}}
+	s, err := engine.BuildSubset(src, []*schema.Table{employee}, []engine.SubsetRoot{{Table: "employee", Where: "employee_id=4"}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src.scans, src.lookups = 0, 0
+	var ids []string
+	err = s.Source(src).Scan(employee, "", func(values []interface{}) {
+		ids = append(ids, fmt.Sprint(values[0]))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(ids, ","); got != "1,2,3,4" {
+		t.Errorf("got rows %s, want 1,2,3,4 (parents first, without 9)", got)
+	}
+	if src.scans != 0 || src.lookups != 1 {
+		t.Errorf("%d scans and %d lookups, want one batched key lookup", src.scans, src.lookups)
+	}
+}
+
+// executed는 "recordexec" 드라이버가 실행한 문장과 인자다.
+var executed []string
+
+func init() { sql.Register("recordexec", recordDriver{}) }
+
+type recordDriver struct{}
+type recordConn struct{}
+type recordStmt struct{ query string }
+
+func (recordDriver) Open(string) (driver.Conn, error)    { return recordConn{}, nil }
+func (recordConn) Prepare(q string) (driver.Stmt, error) { return recordStmt{q}, nil }
+func (recordConn) Close() error                          { return nil }
+func (recordConn) Begin() (driver.Tx, error)             { return recordConn{}, nil }
+func (recordConn) Commit() error                         { return nil }
+func (recordConn) Rollback() error                       { return nil }
+func (recordStmt) Close() error                          { return nil }
+func (recordStmt) NumInput() int                         { return -1 }
+func (s recordStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if len(args) > 0 {
+		executed = append(executed, fmt.Sprint(s.query, " ", args))
+	} else {
+		executed = append(executed, s.query)
+	}
+	return driver.RowsAffected(1), nil
+}
+func (recordStmt) Query([]driver.Value) (driver.Rows, error) { return nil, fmt.Errorf("write only") }
+
+func TestSubsetCopy_KeepsIdentityIds(t *testing.T) {
+	store := &schema.Table{Name: "store", Columns: []*schema.Column{
+		{Name: "store_id", DataType: "int", IsPK: true, IsAutoInc: true},
+	}}
+	staff := &schema.Table{Name: "staff",
+		Columns: []*schema.Column{
+			{Name: "staff_id", DataType: "int", IsPK: true, IsAutoInc: true},
+			{Name: "store_id", DataType: "int"},
+		},
+		ForeignKeys: []*schema.ForeignKey{{Column: "store_id", RefTable: "store", RefColumn: "store_id"}},
+	}
+	src := memorySource{
+		"store": {{7}, {8}},
+		"staff": {{70, 7}, {80, 8}},
+	}
+	s, err := engine.BuildSubset(src, []*schema.Table{store, staff}, []engine.SubsetRoot{{Table: "store", Where: "store_id=7"}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, _ := sql.Open("recordexec", "")
+	defer db.Close()
+	executed = nil
+	results, err := engine.Copy(s.Source(src), db, dialect.GetDialect("sqlserver"), s.Tables(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.Actual != 1 {
+			t.Errorf("%s: %d rows written, want 1", r.TableName, r.Actual)
+		}
+	}
+
+	// 원본 ID를 그대로 넣어야 staff.store_id가 복사된 store를 가리킨다
+	want := []string{
+		"ALTER TABLE [store] NOCHECK CONSTRAINT all",
+		"SET IDENTITY_INSERT [store] ON",
+		"INSERT INTO [store] ([store_id]) VALUES (@p1) [7]",
+		"SET IDENTITY_INSERT [store] OFF",
+		"DBCC CHECKIDENT ('[store]', RESEED)",
+		"ALTER TABLE [staff] NOCHECK CONSTRAINT all",
+		"SET IDENTITY_INSERT [staff] ON",
+		"INSERT INTO [staff] ([staff_id], [store_id]) VALUES (@p1, @p2) [70 7]",
+		"SET IDENTITY_INSERT [staff] OFF",
+		"DBCC CHECKIDENT ('[staff]', RESEED)",
+	}
+	if got := strings.Join(executed, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("executed:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,198 @@
+package engine
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/schema"
+)
+
+// TemporalConfig keeps the date columns of a row in a plausible order:
+// created ≤ updated, start ≤ end, and child rows no older than the parent rows they reference.
+type TemporalConfig struct {
+	Infer      bool                 `mapstructure:"infer"`        // derive start/end roles from column names and meanings
+	MaxGapDays int                  `mapstructure:"max_gap_days"` // an end column lands at most this many days after its start
+	Rules      []TemporalRuleConfig `mapstructure:"rules"`
+}
+
+// TemporalRuleConfig pins one column after another column of the same row,
+// overriding (or adding to) what the column names suggest.
+type TemporalRuleConfig struct {
+	Table  string `mapstructure:"table"` // optional; empty matches the column in any table
+	Column string `mapstructure:"column"`
+	After  string `mapstructure:"after"`
+}
+
+// parentTimes holds, per referenced table, the creation time of each row keyed by its PK
+// (see uniqueKeyOf). It is filled by updateFKPool as tables are pumped.
+var parentTimes = make(map[string]map[string]time.Time)
+
+// isDateType reports whether values of the type carry a calendar date (TIME alone does not).
+func isDateType(dataType string) bool {
+	t := strings.ToLower(dataType)
+	return strings.Contains(t, "date") || strings.Contains(t, "timestamp")
+}
+
+// temporalAfter returns the column that a configured rule places before col, if any.
+func temporalAfter(col *schema.Column, tableName string) string {
+	for _, r := range Settings.Temporal.Rules {
+		if strings.EqualFold(r.Column, col.Name) && (r.Table == "" || strings.EqualFold(r.Table, tableName)) {
+			return r.After
+		}
+	}
+	return ""
+}
+
+// temporalRole is the column's inferred role, or "" when inference is turned off.
+func temporalRole(col *schema.Column) string {
+	if !Settings.Temporal.Infer {
+		return ""
+	}
+	return col.Temporal
+}
+
+// isTemporalDependent reports whether col has to be generated after the other columns of
+// its row because its value depends on them.
+func isTemporalDependent(col *schema.Column, tableName string) bool {
+	if !isDateType(col.DataType) {
+		return false
+	}
+	return temporalRole(col) == schema.TemporalEnd || temporalAfter(col, tableName) != ""
+}
+
+// generateTime draws a timestamp for a date column, honouring the row's timeline:
+// a rule's After column or the row's start/latest moment for end columns, and the
+// referenced parent rows for every column.
+func generateTime(col *schema.Column, tableName string) time.Time {
+	lo, hi := dateWindowFor(col, tableName)
+	if currentRow.notBefore.After(lo) {
+		lo = currentRow.notBefore
+	}
+	if lo.After(hi) {
+		lo = hi
+	}
+
+	name := strings.ToLower(col.Name)
+	role := temporalRole(col)
+
+	var v time.Time
+	if after := temporalAfter(col, tableName); after != "" {
+		base, ok := currentRow.times[strings.ToLower(after)]
+		if !ok || base.Before(lo) {
+			base = lo
+		}
+		v = timeAfter(base, hi)
+	} else if role == schema.TemporalEnd {
+		base := currentRow.latest
+		if base.Before(lo) {
+			base = lo
+		}
+		v = timeAfter(base, hi)
+	} else if role == schema.TemporalStart && !currentRow.start.IsZero() {
+		v = currentRow.start // created_at / reg_dt of one row describe the same moment
+	} else {
+		v = drawTime(lo, hi)
+	}
+
+	if role == schema.TemporalStart && currentRow.start.IsZero() {
+		currentRow.start = v
+	}
+	if role != schema.TemporalEnd && v.After(currentRow.latest) {
+		currentRow.latest = v
+	}
+	if currentRow.times == nil {
+		currentRow.times = make(map[string]time.Time)
+	}
+	currentRow.times[name] = v
+	return v
+}
+
+// timeAfter picks a moment in [base, min(hi, base+max_gap_days)].
+func timeAfter(base, hi time.Time) time.Time {
+	upper := hi
+	if gap := Settings.Temporal.MaxGapDays; gap > 0 {
+		if limit := base.AddDate(0, 0, gap); limit.Before(upper) {
+			upper = limit
+		}
+	}
+	if !upper.After(base) {
+		return base
+	}
+	return gofakeit.DateRange(base, upper)
+}
+
+// noteParentRow raises the row's lower time bound to the creation time of the referenced row.
+func noteParentRow(refTable string, id interface{}) {
+	if t, ok := parentTimes[refTable][uniqueKeyOf(id)]; ok && t.After(currentRow.notBefore) {
+		currentRow.notBefore = t
+	}
+}
+
+// parentTimeColumn picks the column that dates a row of the table: its first start column,
+// otherwise its first date column without a role.
+func parentTimeColumn(table *schema.Table) *schema.Column {
+	var fallback *schema.Column
+	for _, c := range table.Columns {
+		if !isDateType(c.DataType) {
+			continue
+		}
+		switch c.Temporal {
+		case schema.TemporalStart:
+			return c
+		case "":
+			if fallback == nil {
+				fallback = c
+			}
+		}
+	}
+	return fallback
+}
+
+// loadParentTimes records the creation time of every row of a pumped table so that
+// child rows referencing it are dated no earlier.
+func loadParentTimes(db *sql.DB, d dialect.Dialect, table *schema.Table, pk string) {
+	tc := parentTimeColumn(table)
+	if tc == nil || !Settings.Temporal.Infer {
+		return
+	}
+	rows, err := db.Query(fmt.Sprintf("SELECT %s, %s FROM %s", d.QuoteIdent(pk), d.QuoteIdent(tc.Name), d.QuoteIdent(table.Name)))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	times := make(map[string]time.Time)
+	for rows.Next() {
+		var id, raw interface{}
+		if err := rows.Scan(&id, &raw); err != nil {
+			continue
+		}
+		if t, ok := parseTimeValue(raw); ok {
+			times[uniqueKeyOf(id)] = t
+		}
+	}
+	parentTimes[table.Name] = times
+}
+
+// parseTimeValue reads a scanned date value. Generated values are written as wall-clock
+// strings, so the result is always the wall clock in settings.timezone.
+func parseTimeValue(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, dateLocation()), true
+	case []byte:
+		return parseTimeValue(string(t))
+	case string:
+		for _, layout := range []string{"2006-01-02 15:04:05", time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+			if p, err := time.ParseInLocation(layout, t, dateLocation()); err == nil {
+				return parseTimeValue(p)
+			}
+		}
+	}
+	return time.Time{}, false
+}
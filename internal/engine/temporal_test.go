@@ -0,0 +1,22 @@
+package engine
+
+import (
+	"testing"
+
+	"db-pump/internal/schema"
+)
+
+func TestGenerateValue_EndDateFollowsStartDate(t *testing.T) {
+	created := &schema.Column{Name: "created_at", DataType: "datetime", Temporal: schema.TemporalStart}
+	updated := &schema.Column{Name: "updated_at", DataType: "datetime", Temporal: schema.TemporalEnd}
+
+	for i := 0; i < 200; i++ {
+		beginRow() // 행마다 시작 시각을 새로 정함
+		c := GenerateValue(created, "users").(string)
+		u := GenerateValue(updated, "users").(string)
+		// "2006-01-02 15:04:05" 형식은 문자열 비교로 시간 순서를 비교할 수 있다
+		if u < c {
+			t.Fatalf("row %d: updated_at %s precedes created_at %s", i, u, c)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"db-pump/internal/schema"
+
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// generateSpecialType handles column types that are neither text, number nor date:
+// UUID, JSON, network addresses, intervals, XML, money and hstore.
+// It runs before the generic branches because names like "interval" would
+// otherwise be caught by the "int" match. ok is false for any other type.
+func generateSpecialType(col *schema.Column, tableName string) (interface{}, bool) {
+	switch strings.ToLower(col.DataType) {
+	case "uuid", "uniqueidentifier":
+		return gofakeit.UUID(), true
+	case "json", "jsonb":
+		return generateJSON(col, tableName), true
+	case "inet":
+		return gofakeit.IPv4Address(), true
+	case "cidr":
+		return fmt.Sprintf("%d.%d.%d.0/24", 10+seededRand.Intn(200), seededRand.Intn(256), seededRand.Intn(256)), true
+	case "macaddr", "macaddr8":
+		return gofakeit.MacAddress(), true
+	case "interval":
+		return fmt.Sprintf("%d days %02d:%02d:%02d", seededRand.Intn(365), seededRand.Intn(24), seededRand.Intn(60), seededRand.Intn(60)), true
+	case "xml", "xmltype":
+		return generateXML(), true
+	case "money":
+		return fmt.Sprintf("%.2f", gofakeit.Price(1, 100000)), true
+	case "hstore":
+		return generateHstore(), true
+	}
+	return nil, false
+}
+
+// generateXML returns a small well-formed document.
+func generateXML() string {
+	var name bytes.Buffer
+	xml.EscapeText(&name, []byte(activeLocale().Name()))
+	return fmt.Sprintf(`<item id="%d"><name>%s</name><qty>%d</qty></item>`,
+		seededRand.Intn(100000)+1, name.String(), seededRand.Intn(100)+1)
+}
+
+// generateHstore returns an hstore literal such as "color"=>"red", "size"=>"3".
+func generateHstore() string {
+	keys := []string{"color", "size", "brand", "origin", "grade"}
+	n := 1 + seededRand.Intn(len(keys))
+	pairs := make([]string, 0, n)
+	for _, i := range seededRand.Perm(len(keys))[:n] {
+		pairs = append(pairs, fmt.Sprintf(`"%s"=>"%s"`, keys[i], strings.ToLower(gofakeit.Word())))
+	}
+	return strings.Join(pairs, ", ")
+}
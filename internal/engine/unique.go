@@ -0,0 +1,222 @@
+package engine
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/schema"
+)
+
+// UniqueConfig controls how UNIQUE columns are checked against rows already in the table.
+type UniqueConfig struct {
+	Preload        bool `mapstructure:"preload"`         // load existing UNIQUE values before pumping
+	BloomThreshold int  `mapstructure:"bloom_threshold"` // above this many rows a bloom filter replaces the exact set
+}
+
+// valueSet remembers UNIQUE values (or value tuples) already present or generated.
+type valueSet interface {
+	Has(key string) bool
+	Add(key string)
+}
+
+type exactSet map[string]struct{}
+
+func (s exactSet) Has(key string) bool { _, ok := s[key]; return ok }
+func (s exactSet) Add(key string)      { s[key] = struct{}{} }
+
+// bloomSet trades exactness for memory on large tables. A false positive only
+// makes us discard a value that was actually free, never insert a duplicate.
+type bloomSet struct {
+	bits []uint64
+	m    uint64
+	k    uint64
+}
+
+// newBloomSet sizes the filter for n entries at a ~1% false-positive rate.
+func newBloomSet(n int) *bloomSet {
+	m := uint64(math.Ceil(-float64(n) * math.Log(0.01) / (math.Ln2 * math.Ln2)))
+	if m < 64 {
+		m = 64
+	}
+	return &bloomSet{bits: make([]uint64, (m+63)/64), m: m, k: 7}
+}
+
+func (b *bloomSet) hashes(key string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h1 := h.Sum64()
+	return h1, h1>>33 | h1<<31 | 1
+}
+
+func (b *bloomSet) Has(key string) bool {
+	h1, h2 := b.hashes(key)
+	for i := uint64(0); i < b.k; i++ {
+		bit := (h1 + i*h2) % b.m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *bloomSet) Add(key string) {
+	h1, h2 := b.hashes(key)
+	for i := uint64(0); i < b.k; i++ {
+		bit := (h1 + i*h2) % b.m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// newValueSet picks an exact set or a bloom filter for the expected number of entries.
+func newValueSet(expected int) valueSet {
+	if Settings.Unique.BloomThreshold > 0 && expected > Settings.Unique.BloomThreshold {
+		return newBloomSet(expected)
+	}
+	return make(exactSet)
+}
+
+// uniqueKeyOf normalizes generated and scanned values to one comparable form
+// (drivers return []byte for text and time.Time for dates).
+func uniqueKeyOf(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "\x00NULL"
+	case []byte:
+		return string(t)
+	case time.Time:
+		return t.Format("2006-01-02 15:04:05")
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+// scannedUniqueValue turns a value read from the table into the form the generator
+// produces for the column, so that uniqueKeyOf gives both the same key: drivers
+// return DECIMAL as text ("12.50" for 12.5) and MSSQL uniqueidentifier as 16 raw bytes.
+func scannedUniqueValue(c *schema.Column, v interface{}) interface{} {
+	b, ok := v.([]byte)
+	if !ok {
+		return v
+	}
+	t := strings.ToLower(c.DataType)
+	switch {
+	case t == "uniqueidentifier" && len(b) == 16:
+		// SQL Server stores the first three groups little-endian
+		return fmt.Sprintf("%02x%02x%02x%02x-%02x%02x-%02x%02x-%x-%x",
+			b[3], b[2], b[1], b[0], b[5], b[4], b[7], b[6], b[8:10], b[10:])
+	case strings.Contains(t, "decimal") || strings.Contains(t, "numeric") || strings.Contains(t, "money") ||
+		strings.Contains(t, "float") || strings.Contains(t, "double") || strings.Contains(t, "real"):
+		if f, err := strconv.ParseFloat(strings.TrimSpace(string(b)), 64); err == nil {
+			return f
+		}
+	}
+	return normalizeScanned(c, v)
+}
+
+// tupleKeyOf builds the combination key for a composite PK / UNIQUE tuple.
+func tupleKeyOf(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = uniqueKeyOf(v)
+	}
+	return strings.Join(parts, "|")
+}
+
+// preloadUniqueValues reads the UNIQUE columns and tuples already stored in the table
+// so generated rows don't collide with them and get silently dropped by the DB.
+func preloadUniqueValues(db *sql.DB, d dialect.Dialect, table string, insertCols []*schema.Column,
+	used map[string]valueSet, tupleKeys [][]int, usedCombinations []valueSet) error {
+
+	// One SELECT covering every column involved in a single or composite key
+	var idxs []int
+	seen := make(map[int]bool)
+	for i, c := range insertCols {
+		if c.IsUnique {
+			idxs, seen[i] = append(idxs, i), true
+		}
+	}
+	for _, key := range tupleKeys {
+		for _, i := range key {
+			if !seen[i] {
+				idxs, seen[i] = append(idxs, i), true
+			}
+		}
+	}
+	if len(idxs) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(idxs))
+	pos := make(map[int]int)
+	for j, i := range idxs {
+		names[j] = d.QuoteIdent(insertCols[i].Name)
+		pos[i] = j
+	}
+	rows, err := db.Query(fmt.Sprintf("SELECT %s FROM %s", strings.Join(names, ", "), d.QuoteIdent(table)))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	raw := make([]interface{}, len(idxs))
+	ptrs := make([]interface{}, len(idxs))
+	for j := range raw {
+		ptrs[j] = &raw[j]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		for _, i := range idxs {
+			raw[pos[i]] = scannedUniqueValue(insertCols[i], raw[pos[i]])
+			if c := insertCols[i]; c.IsUnique && raw[pos[i]] != nil {
+				used[c.Name].Add(uniqueKeyOf(raw[pos[i]]))
+			}
+		}
+		for k, key := range tupleKeys {
+			vals := make([]interface{}, len(key))
+			for j, i := range key {
+				vals[j] = raw[pos[i]]
+			}
+			usedCombinations[k].Add(tupleKeyOf(vals))
+		}
+	}
+	return rows.Err()
+}
+
+// isStringColumn reports whether a sequence suffix can make a colliding value unique.
+func isStringColumn(col *schema.Column) bool {
+	t := strings.ToLower(col.DataType)
+	return len(col.EnumValues) == 0 && col.Check == nil &&
+		(strings.Contains(t, "char") || strings.Contains(t, "text") || strings.Contains(t, "string"))
+}
+
+// uniqueString makes a colliding string unique by appending seq, e.g.
+// "kim@example.com" → "kim17@example.com", "김민준" → "김민준17".
+// The base is shortened (by runes) when the result would exceed the column length.
+func uniqueString(v string, seq int, length int) string {
+	suffix := strconv.Itoa(seq)
+	local, domain := v, ""
+	if at := strings.LastIndex(v, "@"); at > 0 {
+		local, domain = v[:at], v[at:]
+	}
+	if length > 0 {
+		room := length - len([]rune(domain)) - len(suffix)
+		if room < 0 {
+			// Not even the suffix fits next to the domain: fall back to a plain sequence
+			return truncate(suffix, length)
+		}
+		if room == 0 {
+			local = ""
+		} else {
+			local = truncate(local, room)
+		}
+	}
+	return local + suffix + domain
+}
@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"testing"
+
+	"db-pump/internal/dialect"
+	"db-pump/internal/schema"
+)
+
+// fakeRows is the result every query of the "fakerows" driver returns.
+var fakeRows [][]driver.Value
+
+func init() { sql.Register("fakerows", fakeDriver{}) }
+
+type fakeDriver struct{}
+type fakeConn struct{}
+type fakeStmt struct{}
+type fakeResult struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+func (fakeDriver) Open(string) (driver.Conn, error)         { return fakeConn{}, nil }
+func (fakeConn) Prepare(string) (driver.Stmt, error)        { return fakeStmt{}, nil }
+func (fakeConn) Close() error                               { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                  { return nil, fmt.Errorf("read only") }
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return 0 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, fmt.Errorf("read only") }
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeResult{cols: make([]string, len(fakeRows[0])), rows: fakeRows}, nil
+}
+func (r *fakeResult) Columns() []string { return r.cols }
+func (r *fakeResult) Close() error      { return nil }
+func (r *fakeResult) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestPreloadUniqueValues_MatchesGeneratedKeys(t *testing.T) {
+	guid := &schema.Column{Name: "guid", DataType: "uniqueidentifier", IsUnique: true}
+	price := &schema.Column{Name: "price", DataType: "decimal", Precision: 5, Scale: 2, IsUnique: true}
+	code := &schema.Column{Name: "code", DataType: "varchar", Length: 10, IsUnique: true}
+	cols := []*schema.Column{guid, price, code}
+
+	// MSSQL은 uniqueidentifier를 앞 세 그룹이 리틀 엔디언인 16바이트로, DECIMAL은 문자열로 돌려줌
+	raw := []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+	fakeRows = [][]driver.Value{{raw, []byte("12.50"), []byte("A-1")}}
+	db, _ := sql.Open("fakerows", "")
+	defer db.Close()
+
+	used := map[string]valueSet{"guid": make(exactSet), "price": make(exactSet), "code": make(exactSet)}
+	tuples := [][]int{{1, 2}}
+	combos := []valueSet{make(exactSet)}
+	if err := preloadUniqueValues(db, dialect.GetDialect("mssql"), "item", cols, used, tuples, combos); err != nil {
+		t.Fatal(err)
+	}
+
+	// 생성기가 만드는 형태와 같은 키여야 충돌을 알아챔
+	if !used["guid"].Has(uniqueKeyOf("00112233-4455-6677-8899-aabbccddeeff")) {
+		t.Errorf("uniqueidentifier not normalized: %v", used["guid"])
+	}
+	if !used["price"].Has(uniqueKeyOf(12.5)) {
+		t.Errorf("decimal not normalized: %v", used["price"])
+	}
+	if !used["code"].Has(uniqueKeyOf("A-1")) {
+		t.Errorf("string not preloaded: %v", used["code"])
+	}
+	if !combos[0].Has(tupleKeyOf([]interface{}{12.5, "A-1"})) {
+		t.Errorf("tuple not normalized: %v", combos[0])
+	}
+}
+
+func TestValueSets(t *testing.T) {
+	for name, s := range map[string]valueSet{"exact": make(exactSet), "bloom": newBloomSet(1000)} {
+		for i := 0; i < 1000; i++ {
+			s.Add(fmt.Sprintf("user%d@example.com", i))
+		}
+		// 넣은 값은 항상 있어야 하고(false negative 없음), 없는 값의 오탐은 드물어야 함
+		for i := 0; i < 1000; i++ {
+			if !s.Has(fmt.Sprintf("user%d@example.com", i)) {
+				t.Fatalf("%s: user%d missing", name, i)
+			}
+		}
+		falsePositives := 0
+		for i := 1000; i < 11000; i++ {
+			if s.Has(fmt.Sprintf("user%d@example.com", i)) {
+				falsePositives++
+			}
+		}
+		if falsePositives > 300 {
+			t.Errorf("%s: %d/10000 false positives", name, falsePositives)
+		}
+		if name == "exact" && falsePositives > 0 {
+			t.Errorf("exact set reported %d values it never saw", falsePositives)
+		}
+	}
+}
+
+func TestUniqueString(t *testing.T) {
+	for _, tc := range []struct {
+		v      string
+		seq    int
+		length int
+		want   string
+	}{
+		{"kim@example.com", 17, 0, "kim17@example.com"},
+		{"김민준", 17, 0, "김민준17"},
+		{"kim@example.com", 17, 16, "ki17@example.com"}, // 도메인은 유지하고 앞부분을 줄임
+		{"김민준", 123, 4, "김123"},                         // 룬 단위로 자름
+		{"kim@example.com", 17, 13, "17"},               // 도메인조차 못 넣으면 순번만
+		{"ABCDE", 7, 5, "ABCD7"},
+	} {
+		got := uniqueString(tc.v, tc.seq, tc.length)
+		if got != tc.want {
+			t.Errorf("uniqueString(%q, %d, %d) = %q, want %q", tc.v, tc.seq, tc.length, got, tc.want)
+		}
+		if tc.length > 0 && len([]rune(got)) > tc.length {
+			t.Errorf("%q exceeds %d characters", got, tc.length)
+		}
+	}
+}
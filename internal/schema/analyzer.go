@@ -51,10 +51,10 @@ func Analyze(db *sql.DB, d dialect.Dialect, schemaName string) ([]*Table, error)
 	defer colRows.Close()
 
 	for colRows.Next() {
-		var tName, cName, dType, cType, isNull, cKey, extra, isUnique, comment sql.NullString
-		var cLen sql.NullString // Use String for safety
+		var tName, cName, dType, cType, isNull, cKey, extra, isUnique, comment, isUnsigned, isGenerated, defVal sql.NullString
+		var cLen, cPrec, cScale sql.NullString // Use String for safety
 
-		if err := colRows.Scan(&tName, &cName, &dType, &cType, &cLen, &isNull, &cKey, &extra, &isUnique, &comment); err != nil {
+		if err := colRows.Scan(&tName, &cName, &dType, &cType, &cLen, &isNull, &cKey, &extra, &isUnique, &comment, &cPrec, &cScale, &isUnsigned, &isGenerated, &defVal); err != nil {
 			// Log warning but continue? Or fail? Better to fail if schema is inconsistent.
 			// But for resilience, we might skip. However, "Atomic" implies all or nothing.
 			return nil, fmt.Errorf("failed to scan column (table: %s): %w", tName.String, err)
@@ -88,27 +88,45 @@ func Analyze(db *sql.DB, d dialect.Dialect, schemaName string) ([]*Table, error)
 			meaning := AnalyzeMeaning(cName.String, comment.String)
 
 			col := &Column{
-				Name:       cName.String,
-				DataType:   d.NormalizeType(dType.String), // Use raw type if cType complex expression failed
-				IsNullable: isNull.String == "YES",
-				IsPK:       isPK,
-				IsAutoInc:  isAutoInc,
-				IsUnique:   isUniqueCol,
-				Comment:    comment.String,
-				Meaning:    meaning,
+				Name:        cName.String,
+				DataType:    d.NormalizeType(dType.String), // Use raw type if cType complex expression failed
+				IsNullable:  isNull.String == "YES",
+				IsPK:        isPK,
+				IsAutoInc:   isAutoInc,
+				IsUnique:    isUniqueCol,
+				IsGenerated: isGenerated.String == "YES",
+				Comment:     comment.String,
+				Meaning:     meaning,
+				Temporal:    TemporalRole(cName.String, meaning),
 			}
 
-			// Handle Length safely
-			if cLen.Valid && cLen.String != "" {
-				var length int
-				if _, err := fmt.Sscanf(cLen.String, "%d", &length); err == nil {
-					col.Length = length
-				} else {
-					var fLength float64
-					if _, err := fmt.Sscanf(cLen.String, "%f", &fLength); err == nil {
-						col.Length = int(fLength)
-					}
-				}
+			// MySQL ENUM / SET: members are only visible in COLUMN_TYPE
+			// (PostgreSQL enum labels are reported in the same shape)
+			if cType.Valid {
+				col.EnumValues = ParseEnumType(cType.String)
+			}
+
+			// PostgreSQL extension / user-defined types (hstore, geometry, ...): udt_name carries the real type
+			if strings.EqualFold(dType.String, "USER-DEFINED") && len(col.EnumValues) == 0 && cType.Valid {
+				col.DataType = d.NormalizeType(cType.String)
+			}
+
+			// PostgreSQL arrays: data_type 'ARRAY', udt_name '_text', '_int4', ...
+			if strings.EqualFold(dType.String, "ARRAY") && strings.HasPrefix(cType.String, "_") {
+				col.DataType = "array"
+				col.ElementType = d.NormalizeType(strings.TrimPrefix(cType.String, "_"))
+			}
+
+			// Handle Length / Precision / Scale safely
+			col.Length = parseNullInt(cLen)
+			col.Precision = parseNullInt(cPrec)
+			col.Scale = parseNullInt(cScale)
+			col.Unsigned = isUnsigned.String == "YES"
+
+			// DEFAULT (MariaDB reports a missing default as the string "NULL")
+			if defVal.Valid && !strings.EqualFold(strings.TrimSpace(defVal.String), "NULL") {
+				col.HasDefault = true
+				col.Default = strings.TrimSpace(defVal.String)
 			}
 			t.Columns = append(t.Columns, col)
 		}
@@ -117,6 +135,37 @@ func Analyze(db *sql.DB, d dialect.Dialect, schemaName string) ([]*Table, error)
 		return nil, fmt.Errorf("error iterating columns: %w", err)
 	}
 
+	// --- Step 2.5: Fetch CHECK Constraints ---
+	// Not fatal: older servers (e.g. MySQL < 8.0.16) have no catalog for them.
+	if err := applyCheckConstraints(db, d, target, tableMap); err != nil {
+		fmt.Printf("[Check] Warning: CHECK constraints not analyzed: %v\n", err)
+	}
+
+	// --- Step 2.6: Fetch UNIQUE Keys (column sets) ---
+	// Not fatal: without it we keep the per-column UNIQUE flags from the columns query.
+	if err := applyUniqueKeys(db, d, target, tableMap); err != nil {
+		fmt.Printf("[Unique] Warning: UNIQUE keys not analyzed: %v\n", err)
+	}
+
+	// --- Step 2.7: Fetch Partitions ---
+	// Not fatal: without it rows are still routed by the DB, only unspread.
+	children, err := applyPartitions(db, d, target, tableMap)
+	if err != nil {
+		fmt.Printf("[Partition] Warning: partitions not analyzed: %v\n", err)
+	}
+	if len(children) > 0 {
+		// PostgreSQL partitions are filled through their parent
+		kept := tables[:0]
+		for _, t := range tables {
+			if children[strings.ToUpper(t.Name)] {
+				delete(tableMap, strings.ToUpper(t.Name))
+				continue
+			}
+			kept = append(kept, t)
+		}
+		tables = kept
+	}
+
 	// --- Step 3: Fetch Foreign Keys ---
 	fkRows, err := db.Query(d.GetForeignKeysQuery(target), target)
 	if err != nil {
@@ -159,6 +208,111 @@ func Analyze(db *sql.DB, d dialect.Dialect, schemaName string) ([]*Table, error)
 	return SortTablesByFKCount(tables), nil
 }
 
+// parseNullInt reads an integer that drivers may report as "10", "10.0" or NULL.
+func parseNullInt(ns sql.NullString) int {
+	if !ns.Valid || ns.String == "" {
+		return 0
+	}
+	var n int
+	if _, err := fmt.Sscanf(ns.String, "%d", &n); err == nil {
+		return n
+	}
+	var f float64
+	if _, err := fmt.Sscanf(ns.String, "%f", &f); err == nil {
+		return int(f)
+	}
+	return 0
+}
+
+// applyCheckConstraints parses each CHECK expression and attaches the resulting
+// rules to the matching columns. IN (...) lists also populate EnumValues.
+func applyCheckConstraints(db *sql.DB, d dialect.Dialect, target string, tableMap map[string]*Table) error {
+	rows, err := db.Query(d.GetCheckConstraintsQuery(target), target)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tName, cName, clause sql.NullString
+		if err := rows.Scan(&tName, &cName, &clause); err != nil {
+			return fmt.Errorf("failed to scan check constraint: %w", err)
+		}
+		t, ok := tableMap[strings.ToUpper(tName.String)]
+		if !ok || !clause.Valid {
+			continue
+		}
+		for _, rule := range ParseCheckConstraint(clause.String) {
+			for _, col := range t.Columns {
+				if strings.EqualFold(col.Name, rule.Column) {
+					col.Check = MergeCheckRule(col.Check, rule)
+					if len(col.Check.Values) > 0 {
+						col.EnumValues = col.Check.Values
+					}
+					break
+				}
+			}
+		}
+	}
+	return rows.Err()
+}
+
+// applyUniqueKeys collects UNIQUE constraints/indexes as column sets.
+// The columns query flags every member of a composite key as UNIQUE on some
+// databases, so per-column IsUnique is recomputed: a column is unique on its
+// own only if a single-column key exists for it.
+func applyUniqueKeys(db *sql.DB, d dialect.Dialect, target string, tableMap map[string]*Table) error {
+	rows, err := db.Query(d.GetUniqueKeysQuery(target), target)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type indexKey struct{ table, index string }
+	keyCols := make(map[indexKey][]string)
+	var order []indexKey
+	for rows.Next() {
+		var tName, iName, cName sql.NullString
+		if err := rows.Scan(&tName, &iName, &cName); err != nil {
+			return fmt.Errorf("failed to scan unique key: %w", err)
+		}
+		if !tName.Valid || !iName.Valid || !cName.Valid {
+			continue
+		}
+		k := indexKey{strings.ToUpper(tName.String), iName.String}
+		if _, seen := keyCols[k]; !seen {
+			order = append(order, k)
+		}
+		keyCols[k] = append(keyCols[k], cName.String)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, t := range tableMap {
+		t.UniqueKeys = nil
+		for _, c := range t.Columns {
+			c.IsUnique = false
+		}
+	}
+	for _, k := range order {
+		t, ok := tableMap[k.table]
+		if !ok {
+			continue
+		}
+		cols := keyCols[k]
+		t.UniqueKeys = append(t.UniqueKeys, cols)
+		if len(cols) == 1 {
+			for _, c := range t.Columns {
+				if strings.EqualFold(c.Name, cols[0]) {
+					c.IsUnique = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------
 // 3. Sorting Algorithm (Topological / Greedy)
 // ---------------------------------------------------------------------
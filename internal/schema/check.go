@@ -0,0 +1,333 @@
+package schema
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------------------
+// CHECK Constraint Parser
+// ---------------------------------------------------------------------
+// Each dialect returns the raw CHECK expression in its own flavour:
+//   MySQL    : (`rating` in (_utf8mb4'G',_utf8mb4'PG'))
+//   Postgres : CHECK ((rating = ANY (ARRAY['G'::text, 'PG'::text])))
+//   MSSQL    : ([rating]='PG' OR [rating]='G')
+//   Oracle   : "RATING" IN ('G','PG')
+// The parser normalizes these and extracts only the simple, column-local
+// forms the generator can honour: IN (...), BETWEEN, >=/<=/>/<, LIKE 'prefix%'.
+// Anything else (functions, column-to-column comparisons) is ignored.
+
+var (
+	reCheckKeyword = regexp.MustCompile(`(?i)^\s*CHECK\s*`)
+	reCharsetIntro = regexp.MustCompile(`(?i)(^|[\s(,=])_[a-z0-9]+'`) // only at token start: 'in_progress' is a value
+	reTypeCast     = regexp.MustCompile(`::("[^"]+"|character varying|double precision|(?:timestamp|time) (?:with|without) time zone|[\w.]+)(\(\d+(?:,\s*\d+)?\))?(\[\])?`)
+	reAnyArray2    = regexp.MustCompile(`(?i)=\s*ANY\s*\(\s*\(\s*ARRAY\s*\[(.*?)\]\s*\)\s*\)`)
+	reAnyArray     = regexp.MustCompile(`(?i)=\s*ANY\s*\(\s*ARRAY\s*\[(.*?)\]\s*\)`)
+	reParenIdent   = regexp.MustCompile(`(^|[^\w$#])\(([A-Za-z_][\w$#]*)\)`)
+	reBracketIdent = regexp.MustCompile(`\[([^\]'"]+)\]`)
+	reQuotedIdent  = regexp.MustCompile("[`\"]([^`\"]+)[`\"]")
+	reBetweenTail  = regexp.MustCompile(`(?i)\bBETWEEN\s+\S+\s*$`)
+
+	reIn      = regexp.MustCompile(`(?is)^([\w$#]+)\s+IN\s*\((.*)\)$`)
+	reBetween = regexp.MustCompile(`(?is)^([\w$#]+)\s+BETWEEN\s+(.+?)\s+AND\s+(.+)$`)
+	reLike    = regexp.MustCompile(`(?is)^([\w$#]+)\s+(?:LIKE|~~)\s+(N?'.*')$`)
+	reCompare = regexp.MustCompile(`(?s)^([\w$#]+)\s*(>=|<=|<>|!=|=|>|<)\s*(.+)$`)
+	reRevComp = regexp.MustCompile(`(?s)^(.+?)\s*(>=|<=|>|<)\s*([A-Za-z_][\w$#]*)$`)
+)
+
+// ParseCheckConstraint extracts column rules from a single CHECK expression.
+// The same column may appear in several returned rules; use MergeCheckRule to combine them.
+func ParseCheckConstraint(expr string) []*CheckRule {
+	s := normalizeCheckExpr(expr)
+	var rules []*CheckRule
+	for _, part := range splitConjuncts(s) {
+		if r := parseCheckTerm(stripParens(part)); r != nil {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// MergeCheckRule folds rule r into dst (intersection semantics) and returns the result.
+func MergeCheckRule(dst, r *CheckRule) *CheckRule {
+	if dst == nil {
+		return r
+	}
+	if len(r.Values) > 0 {
+		if len(dst.Values) == 0 {
+			dst.Values = r.Values
+		} else {
+			allowed := make(map[string]bool)
+			for _, v := range r.Values {
+				allowed[v] = true
+			}
+			var both []string
+			for _, v := range dst.Values {
+				if allowed[v] {
+					both = append(both, v)
+				}
+			}
+			dst.Values = both
+		}
+	}
+	if r.Min != nil && (dst.Min == nil || *r.Min > *dst.Min || (*r.Min == *dst.Min && r.MinExclusive)) {
+		dst.Min, dst.MinExclusive = r.Min, r.MinExclusive
+	}
+	if r.Max != nil && (dst.Max == nil || *r.Max < *dst.Max || (*r.Max == *dst.Max && r.MaxExclusive)) {
+		dst.Max, dst.MaxExclusive = r.Max, r.MaxExclusive
+	}
+	if r.Prefix != "" && len(r.Prefix) > len(dst.Prefix) {
+		dst.Prefix = r.Prefix
+	}
+	return dst
+}
+
+func normalizeCheckExpr(expr string) string {
+	s := strings.TrimSpace(expr)
+	s = reCheckKeyword.ReplaceAllString(s, "")
+	s = reCharsetIntro.ReplaceAllString(s, "$1'")
+	s = reTypeCast.ReplaceAllString(s, "")
+	s = reAnyArray2.ReplaceAllString(s, " IN ($1)")
+	s = reAnyArray.ReplaceAllString(s, " IN ($1)")
+	s = reBracketIdent.ReplaceAllString(s, "$1")
+	s = reQuotedIdent.ReplaceAllString(s, "$1")
+	s = reParenIdent.ReplaceAllString(s, "${1}${2}") // Postgres: (code)::text → code
+	return stripParens(s)
+}
+
+// splitConjuncts splits on top-level AND, re-joining the AND that belongs to BETWEEN.
+func splitConjuncts(s string) []string {
+	raw := splitTopLevel(s, "AND")
+	var parts []string
+	for i := 0; i < len(raw); i++ {
+		p := raw[i]
+		if reBetweenTail.MatchString(p) && i+1 < len(raw) {
+			p = p + " AND " + raw[i+1]
+			i++
+		}
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+func parseCheckTerm(term string) *CheckRule {
+	// OR-chain of equalities (MSSQL rewrites IN (...) this way)
+	if ors := splitTopLevel(term, "OR"); len(ors) > 1 {
+		rule := &CheckRule{}
+		for _, o := range ors {
+			m := reCompare.FindStringSubmatch(stripParens(o))
+			if m == nil || m[2] != "=" {
+				return nil
+			}
+			if rule.Column != "" && !strings.EqualFold(rule.Column, m[1]) {
+				return nil
+			}
+			v, ok := parseLiteral(m[3])
+			if !ok {
+				return nil
+			}
+			rule.Column = m[1]
+			rule.Values = append(rule.Values, v)
+		}
+		return rule
+	}
+
+	if m := reIn.FindStringSubmatch(term); m != nil {
+		var values []string
+		for _, item := range splitTopLevel(m[2], ",") {
+			v, ok := parseLiteral(item)
+			if !ok {
+				return nil
+			}
+			values = append(values, v)
+		}
+		return &CheckRule{Column: m[1], Values: values}
+	}
+
+	if m := reBetween.FindStringSubmatch(term); m != nil {
+		lo, ok1 := parseNumber(m[2])
+		hi, ok2 := parseNumber(m[3])
+		if !ok1 || !ok2 {
+			return nil
+		}
+		return &CheckRule{Column: m[1], Min: &lo, Max: &hi}
+	}
+
+	if m := reLike.FindStringSubmatch(term); m != nil {
+		pattern, ok := parseLiteral(m[2])
+		if !ok {
+			return nil
+		}
+		// Only "prefix%" is honoured; other wildcard shapes are left to the DB.
+		prefix := strings.TrimSuffix(pattern, "%")
+		if prefix == "" || prefix == pattern || strings.ContainsAny(prefix, "%_") {
+			return nil
+		}
+		return &CheckRule{Column: m[1], Prefix: prefix}
+	}
+
+	if m := reCompare.FindStringSubmatch(term); m != nil && !isKeyword(m[1]) {
+		return compareRule(m[1], m[2], m[3])
+	}
+	if m := reRevComp.FindStringSubmatch(term); m != nil && !isKeyword(m[3]) {
+		// "0 <= col" → "col >= 0"
+		flip := map[string]string{">=": "<=", "<=": ">=", ">": "<", "<": ">"}
+		return compareRule(m[3], flip[m[2]], m[1])
+	}
+	return nil
+}
+
+func compareRule(col, op, operand string) *CheckRule {
+	if op == "=" {
+		if v, ok := parseLiteral(operand); ok {
+			return &CheckRule{Column: col, Values: []string{v}}
+		}
+		return nil
+	}
+	n, ok := parseNumber(operand)
+	if !ok {
+		return nil
+	}
+	switch op {
+	case ">=":
+		return &CheckRule{Column: col, Min: &n}
+	case ">":
+		return &CheckRule{Column: col, Min: &n, MinExclusive: true}
+	case "<=":
+		return &CheckRule{Column: col, Max: &n}
+	case "<":
+		return &CheckRule{Column: col, Max: &n, MaxExclusive: true}
+	}
+	return nil
+}
+
+func isKeyword(ident string) bool {
+	switch strings.ToUpper(ident) {
+	case "AND", "OR", "NOT", "NULL", "IS", "IN", "LIKE", "BETWEEN":
+		return true
+	}
+	return false
+}
+
+// parseLiteral returns the value of a quoted string or numeric literal.
+func parseLiteral(s string) (string, bool) {
+	s = stripParens(strings.TrimSpace(s))
+	if len(s) > 1 && (s[0] == 'N' || s[0] == 'n') && s[1] == '\'' {
+		s = s[1:]
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return s, true
+	}
+	return "", false
+}
+
+func parseNumber(s string) (float64, bool) {
+	v, ok := parseLiteral(s)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	return n, err == nil
+}
+
+// stripParens removes redundant parentheses wrapping the whole expression.
+func stripParens(s string) string {
+	s = strings.TrimSpace(s)
+	for len(s) >= 2 && s[0] == '(' && matchingParen(s, 0) == len(s)-1 {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s
+}
+
+func matchingParen(s string, open int) int {
+	depth := 0
+	inQuote := false
+	for i := open; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits s on sep (a keyword like AND/OR, or a single punctuation char)
+// outside of quotes and parentheses. Keywords match case-insensitively on word boundaries.
+func splitTopLevel(s, sep string) []string {
+	var parts []string
+	isWord := sep != ","
+	depth, start := 0, 0
+	inQuote := false
+	upper := strings.ToUpper(s)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\'':
+			inQuote = !inQuote
+			continue
+		case inQuote:
+			continue
+		case c == '(':
+			depth++
+			continue
+		case c == ')':
+			depth--
+			continue
+		}
+		if depth != 0 || !strings.HasPrefix(upper[i:], sep) {
+			continue
+		}
+		if isWord {
+			before := i == 0 || !isIdentChar(s[i-1])
+			after := i+len(sep) >= len(s) || !isIdentChar(s[i+len(sep)])
+			if !before || !after {
+				continue
+			}
+		}
+		parts = append(parts, strings.TrimSpace(s[start:i]))
+		start = i + len(sep)
+		i = start - 1
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c == '#' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// ---------------------------------------------------------------------
+// MySQL ENUM / SET Column Types
+// ---------------------------------------------------------------------
+
+var reEnumType = regexp.MustCompile(`(?is)^\s*(enum|set)\s*\((.*)\)\s*$`)
+
+// ParseEnumType extracts the allowed members from a MySQL COLUMN_TYPE such as
+// "enum('G','PG','R')" or "set('Trailers','Commentaries')".
+// It returns nil for any other column type.
+func ParseEnumType(columnType string) []string {
+	m := reEnumType.FindStringSubmatch(columnType)
+	if m == nil {
+		return nil
+	}
+	var values []string
+	for _, item := range splitTopLevel(m[2], ",") {
+		v, ok := parseLiteral(item)
+		if !ok {
+			return nil
+		}
+		values = append(values, v)
+	}
+	return values
+}
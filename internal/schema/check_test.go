@@ -0,0 +1,98 @@
+package schema_test
+
+import (
+	"db-pump/internal/schema"
+	"reflect"
+	"testing"
+)
+
+func TestParseCheckConstraint_Dialects(t *testing.T) {
+	// 각 DB가 돌려주는 CHECK 표현식 형태별로 IN 목록이 동일하게 추출되는지 확인
+	exprs := map[string]string{
+		"mysql":    "(`rating` in (_utf8mb4'G',_utf8mb4'PG',_utf8mb4'R'))",
+		"postgres": "CHECK ((rating = ANY (ARRAY['G'::text, 'PG'::text, 'R'::text])))",
+		"pg-cast":  "CHECK (((rating)::text = ANY ((ARRAY['G'::character varying, 'PG'::character varying, 'R'::character varying])::text[])))",
+		"mssql":    "([rating]='G' OR [rating]='PG' OR [rating]='R')",
+		"oracle":   `"RATING" IN ('G','PG','R')`,
+	}
+	want := []string{"G", "PG", "R"}
+
+	for name, expr := range exprs {
+		rules := schema.ParseCheckConstraint(expr)
+		if len(rules) != 1 {
+			t.Fatalf("%s: expected 1 rule, got %d", name, len(rules))
+		}
+		if !reflect.DeepEqual(rules[0].Values, want) {
+			t.Errorf("%s: values = %v, want %v", name, rules[0].Values, want)
+		}
+	}
+}
+
+func TestParseCheckConstraint_UnderscoreValues(t *testing.T) {
+	// 값 안의 밑줄을 MySQL 문자셋 접두어(_utf8mb4)로 오인하면 안 됨
+	exprs := map[string]string{
+		"mysql":    "(`status` in (_utf8mb4'in_progress',_utf8mb4'done'))",
+		"postgres": "CHECK (((status)::text = ANY ((ARRAY['in_progress'::character varying, 'done'::character varying])::text[])))",
+		"mssql":    "([status]='in_progress' OR [status]='done')",
+		"oracle":   `"STATUS" IN ('in_progress','done')`,
+	}
+	want := []string{"in_progress", "done"}
+
+	for name, expr := range exprs {
+		rules := schema.ParseCheckConstraint(expr)
+		if len(rules) != 1 || !reflect.DeepEqual(rules[0].Values, want) {
+			t.Errorf("%s: got %+v, want values %v", name, rules, want)
+		}
+	}
+}
+
+func TestParseCheckConstraint_Ranges(t *testing.T) {
+	rules := schema.ParseCheckConstraint("CHECK (((release_year >= 1901) AND (release_year <= 2155)))")
+	var merged *schema.CheckRule
+	for _, r := range rules {
+		merged = schema.MergeCheckRule(merged, r)
+	}
+	if merged == nil || *merged.Min != 1901 || *merged.Max != 2155 {
+		t.Fatalf("unexpected range rule: %+v", merged)
+	}
+
+	rules = schema.ParseCheckConstraint("([score] BETWEEN (0) AND (100))")
+	if len(rules) != 1 || *rules[0].Min != 0 || *rules[0].Max != 100 {
+		t.Errorf("BETWEEN not parsed: %+v", rules)
+	}
+
+	rules = schema.ParseCheckConstraint("CHECK ((amount > (0)::numeric))")
+	if len(rules) != 1 || !rules[0].MinExclusive || *rules[0].Min != 0 {
+		t.Errorf("exclusive bound not parsed: %+v", rules)
+	}
+}
+
+func TestParseCheckConstraint_LikePrefix(t *testing.T) {
+	rules := schema.ParseCheckConstraint("CHECK (((code)::text ~~ 'PRD-%'::text))")
+	if len(rules) != 1 || rules[0].Prefix != "PRD-" {
+		t.Errorf("LIKE prefix not parsed: %+v", rules)
+	}
+
+	// 접두어 형태가 아닌 패턴은 무시
+	if rules := schema.ParseCheckConstraint("(`code` like '%X%')"); len(rules) != 0 {
+		t.Errorf("expected no rule for non-prefix pattern, got %+v", rules)
+	}
+}
+
+func TestParseEnumType(t *testing.T) {
+	got := schema.ParseEnumType("set('Trailers','Commentaries','Deleted Scenes','Behind the Scenes')")
+	want := []string{"Trailers", "Commentaries", "Deleted Scenes", "Behind the Scenes"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("set members = %v, want %v", got, want)
+	}
+
+	// 쉼표와 이스케이프된 따옴표가 포함된 멤버
+	got = schema.ParseEnumType("enum('a,b','it''s')")
+	if !reflect.DeepEqual(got, []string{"a,b", "it's"}) {
+		t.Errorf("enum members = %v", got)
+	}
+
+	if schema.ParseEnumType("varchar(20)") != nil {
+		t.Error("non-enum type should return nil")
+	}
+}
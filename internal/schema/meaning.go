@@ -26,6 +26,14 @@ var abbreviations = map[string]string{
 	"bg": "background", "fg": "foreground",
 	"brd": "board", "art": "article", "auth": "authority",
 	"is": "yesno", "use": "yesno", "flg": "flag",
+
+	// Korean identifiers
+	"rrn": "rrn", "jumin": "rrn", "bizno": "bizno", "brn": "bizno", "saupja": "bizno",
+	"corpno": "corpno", "crn": "corpno", "acct": "account", "acnt": "account", "cardno": "card number",
+
+	// Person
+	"sex": "gender", "gndr": "gender", "dob": "birth date", "brth": "birth", "bday": "birthday",
+	"birthdt": "birth date", "nick": "nickname", "fnm": "first name", "lnm": "last name",
 }
 
 func AnalyzeMeaning(colName, comment string) string {
@@ -33,6 +41,31 @@ func AnalyzeMeaning(colName, comment string) string {
 	n := strings.ToLower(colName)
 
 	// 1. Priority based on comment keywords (Korean/English)
+	// Identifiers first: "주민등록번호" must not fall through to the generic keywords below
+	if strings.Contains(c, "주민번호") || strings.Contains(c, "주민등록번호") {
+		return "rrn"
+	}
+	if strings.Contains(c, "사업자번호") || strings.Contains(c, "사업자등록번호") {
+		return "bizno"
+	}
+	if strings.Contains(c, "법인번호") || strings.Contains(c, "법인등록번호") {
+		return "corpno"
+	}
+	if strings.Contains(c, "카드번호") {
+		return "card number"
+	}
+	if strings.Contains(c, "계좌") {
+		return "account number"
+	}
+	if strings.Contains(c, "성별") || strings.Contains(c, "gender") {
+		return "gender"
+	}
+	if strings.Contains(c, "생년월일") || strings.Contains(c, "생일") || strings.Contains(c, "birth") {
+		return "birth date"
+	}
+	if strings.Contains(c, "닉네임") || strings.Contains(c, "별명") || strings.Contains(c, "nickname") {
+		return "nickname"
+	}
 	if strings.Contains(c, "전화") || strings.Contains(c, "휴대폰") || strings.Contains(c, "연락처") ||
 		strings.Contains(c, "핸드폰") || strings.Contains(c, "mobile") || strings.Contains(c, "phone") {
 		return "phone"
@@ -96,3 +129,47 @@ func AnalyzeMeaning(colName, comment string) string {
 
 	return strings.Join(decodedParts, " ")
 }
+
+// Temporal roles of date/time columns within a row's timeline.
+const (
+	TemporalStart = "start" // 생성/등록/시작 시점 (created_at, reg_dt, start_date, rental_date)
+	TemporalEnd   = "end"   // 시작 이후 시점 (updated_at, mod_dt, end_date, return_date, expire_dt)
+)
+
+// 단어 단위로만 비교할 짧은 키워드 (부분 문자열로 비교하면 "attend", "spend" 등이 걸림)
+var temporalEndWords = map[string]bool{"end": true, "due": true, "last": true, "mod": true, "upd": true, "exp": true, "to": true, "until": true}
+var temporalStartWords = map[string]bool{"reg": true, "cre": true, "ins": true, "from": true, "since": true}
+
+var temporalEndStems = []string{"updat", "modif", "finish", "return", "expir", "close", "delet", "complet", "ship", "deliver", "cancel", "resolv", "terminat"}
+var temporalStartStems = []string{"creat", "regist", "start", "begin", "issue", "order", "open", "rental", "hire", "join", "publish", "insert", "request", "receiv"}
+
+// TemporalRole classifies a column by its column name and analyzed meaning:
+// TemporalStart for creation/start moments, TemporalEnd for moments that must not
+// precede them, "" for independent dates such as a birth date.
+func TemporalRole(colName, meaning string) string {
+	words := strings.FieldsFunc(strings.ToLower(colName+" "+meaning), func(r rune) bool {
+		return r == '_' || r == ' ' || r == '-'
+	})
+
+	for _, w := range words {
+		if temporalEndWords[w] {
+			return TemporalEnd
+		}
+		for _, s := range temporalEndStems {
+			if strings.HasPrefix(w, s) {
+				return TemporalEnd
+			}
+		}
+	}
+	for _, w := range words {
+		if temporalStartWords[w] {
+			return TemporalStart
+		}
+		for _, s := range temporalStartStems {
+			if strings.HasPrefix(w, s) {
+				return TemporalStart
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package schema_test
+
+import (
+	"db-pump/internal/schema"
+	"testing"
+)
+
+func TestTemporalRole(t *testing.T) {
+	cases := map[string]string{
+		"created_at":  schema.TemporalStart,
+		"reg_dt":      schema.TemporalStart,
+		"rental_date": schema.TemporalStart,
+		"start_date":  schema.TemporalStart,
+		"updated_at":  schema.TemporalEnd,
+		"mod_dt":      schema.TemporalEnd,
+		"last_update": schema.TemporalEnd,
+		"return_date": schema.TemporalEnd,
+		"end_date":    schema.TemporalEnd,
+		"birth_date":  "",
+		"attend_dt":   "", // "end"는 단어 단위로만 인식
+	}
+	for name, want := range cases {
+		if got := schema.TemporalRole(name, schema.AnalyzeMeaning(name, "")); got != want {
+			t.Errorf("TemporalRole(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestAnalyzeMeaning_Identifiers(t *testing.T) {
+	cases := []struct{ name, comment, want string }{
+		{"jumin_no", "주민등록번호", "rrn"},
+		{"biz_reg", "사업자번호", "bizno"},
+		{"corp_reg", "법인등록번호", "corpno"},
+		{"bank_acc", "입금 계좌", "account number"},
+		{"rrn", "", "rrn"},
+		{"bizno", "", "bizno"},
+		{"acct_no", "", "account number"},
+		{"card_no", "", "card number"},
+	}
+	for _, c := range cases {
+		if got := schema.AnalyzeMeaning(c.name, c.comment); got != c.want {
+			t.Errorf("AnalyzeMeaning(%s, %s) = %q, want %q", c.name, c.comment, got, c.want)
+		}
+	}
+}
+
+func TestAnalyzeMeaning_Person(t *testing.T) {
+	cases := []struct{ name, comment, want string }{
+		{"sex", "", "gender"},
+		{"gndr_cd", "", "gender code"},
+		{"mbr_dob", "", "mbr birth date"},
+		{"brth_dt", "", "birth date"},
+		{"col1", "성별", "gender"},
+		{"col2", "생년월일", "birth date"},
+		{"nick_nm", "", "nickname name"},
+	}
+	for _, c := range cases {
+		if got := schema.AnalyzeMeaning(c.name, c.comment); got != c.want {
+			t.Errorf("AnalyzeMeaning(%s, %s) = %q, want %q", c.name, c.comment, got, c.want)
+		}
+	}
+}
@@ -4,20 +4,51 @@ type Table struct {
 	Name         string
 	Columns      []*Column
 	ForeignKeys  []*ForeignKey
-	Dependencies []string // 의존성 분석용
+	UniqueKeys   [][]string   // UNIQUE 제약/인덱스의 컬럼 집합 (PK 제외, 복합 키 포함)
+	PartitionKey string       // 파티션 키 컬럼 (파티션 테이블이 아니면 "")
+	Partitions   []*Partition // RANGE / LIST 파티션별 값 범위
+	Dependencies []string     // 의존성 분석용
 }
 
 type Column struct {
-	Name       string
-	DataType   string
-	Length     int
-	IsNullable bool
-	IsPK       bool
-	IsAutoInc  bool
-	IsUnique   bool
-	EnumValues []string
-	Comment    string // DB 스키마 코멘트 (MS_Description 등)
-	Meaning    string // 약어 또는 코멘트 분석을 통해 파악된 의미 (예: "phone", "email")
+	Name        string
+	DataType    string
+	Length      int
+	Precision   int  // DECIMAL/NUMERIC 전체 자릿수 (0이면 알 수 없음)
+	Scale       int  // 소수점 이하 자릿수
+	Unsigned    bool // MySQL UNSIGNED, MSSQL tinyint
+	IsNullable  bool
+	IsPK        bool
+	IsAutoInc   bool
+	IsUnique    bool
+	HasDefault  bool   // 컬럼에 DEFAULT가 정의되어 있는지
+	Default     string // DEFAULT 원문 (예: 'ACTIVE'::character varying, (getdate()), CURRENT_TIMESTAMP)
+	IsGenerated bool   // GENERATED ALWAYS AS / computed / rowversion / virtual: DB가 값을 채우므로 INSERT 대상에서 제외
+	EnumValues  []string
+	ElementType string     // 배열 컬럼의 원소 타입 (PostgreSQL text[] → "text"), 배열이 아니면 ""
+	Check       *CheckRule // CHECK 제약에서 추출한 범위/접두어 규칙 (없으면 nil)
+	Comment     string     // DB 스키마 코멘트 (MS_Description 등)
+	Meaning     string     // 약어 또는 코멘트 분석을 통해 파악된 의미 (예: "phone", "email")
+	Temporal    string     // 날짜 컬럼의 행 내 시간 순서 역할 (TemporalStart / TemporalEnd / "")
+}
+
+// CheckRule은 CHECK 제약 중 단일 컬럼에 대한 단순 조건을 표현한다.
+type CheckRule struct {
+	Column       string
+	Values       []string // IN (...) / OR 등호 체인
+	Min          *float64 // >=, >, BETWEEN 하한
+	Max          *float64 // <=, <, BETWEEN 상한
+	MinExclusive bool
+	MaxExclusive bool
+	Prefix       string // LIKE 'ABC%'
+}
+
+// Partition은 파티션 하나가 받는 파티션 키 값의 범위를 표현한다.
+type Partition struct {
+	Name   string
+	From   string   // RANGE 하한 (포함, ""이면 제한 없음)
+	To     string   // RANGE 상한 (미포함, ""이면 제한 없음)
+	Values []string // LIST 파티션 값
 }
 
 type ForeignKey struct {
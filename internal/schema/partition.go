@@ -0,0 +1,215 @@
+package schema
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"db-pump/internal/dialect"
+)
+
+var (
+	rePartRange   = regexp.MustCompile(`(?is)^FOR VALUES FROM \((.*)\) TO \((.*)\)$`)
+	rePartList    = regexp.MustCompile(`(?is)^FOR VALUES IN \((.*)\)$`)
+	rePartKeyFunc = regexp.MustCompile(`^(\w+)\((.+)\)$`)
+	rePartQuoted  = regexp.MustCompile(`'([^']*)'`)
+	rePartCast    = regexp.MustCompile(`::[\w ]+(\(\d+\))?$`)
+)
+
+// applyPartitions reads the partition layout of each partitioned table.
+// PostgreSQL partitions are tables of their own; their names are returned so the
+// caller can drop them from the pump list (rows go through the parent, which routes them).
+func applyPartitions(db *sql.DB, d dialect.Dialect, target string, tableMap map[string]*Table) (map[string]bool, error) {
+	query := d.GetPartitionsQuery(target)
+	if query == "" {
+		return nil, nil
+	}
+	rows, err := db.Query(query, target)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type partitioned struct {
+		method, key   string
+		names, bounds []string
+	}
+	byTable := make(map[string]*partitioned)
+	var order []string
+	children := make(map[string]bool)
+	for rows.Next() {
+		var tName, pName, method, key, bound sql.NullString
+		if err := rows.Scan(&tName, &pName, &method, &key, &bound); err != nil {
+			return nil, fmt.Errorf("failed to scan partition: %w", err)
+		}
+		tKey := strings.ToUpper(tName.String)
+		p, ok := byTable[tKey]
+		if !ok {
+			p = &partitioned{method: method.String, key: key.String}
+			byTable[tKey] = p
+			order = append(order, tKey)
+		}
+		// MySQL lists a partition once per subpartition
+		if n := len(p.names); n > 0 && p.names[n-1] == pName.String {
+			continue
+		}
+		p.names = append(p.names, pName.String)
+		p.bounds = append(p.bounds, bound.String)
+		if _, isTable := tableMap[strings.ToUpper(pName.String)]; isTable {
+			children[strings.ToUpper(pName.String)] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, tKey := range order {
+		t, ok := tableMap[tKey]
+		if !ok {
+			continue
+		}
+		p := byTable[tKey]
+		t.PartitionKey, t.Partitions = BuildPartitions(p.method, p.key, p.names, p.bounds)
+	}
+	return children, nil
+}
+
+// BuildPartitions turns the rows of GetPartitionsQuery for one table into its partition key
+// column and the value range of each partition.
+//
+// Bounds come in two shapes: PostgreSQL gives both ends ("FOR VALUES FROM (...) TO (...)",
+// "FOR VALUES IN (...)"), MySQL and Oracle give only the upper bound of a RANGE partition
+// ("738000", "MAXVALUE", "TO_DATE(' 2022-01-01 00:00:00', ...)") and take the lower bound
+// from the previous partition. Keys wrapped in TO_DAYS / YEAR / UNIX_TIMESTAMP are converted
+// back to dates. HASH partitions and keys the generator can't target yield no partitions;
+// the database still routes every row.
+func BuildPartitions(method, keyExpr string, names, bounds []string) (string, []*Partition) {
+	column, fn := parsePartitionKey(keyExpr)
+	m := strings.ToUpper(method)
+	if column == "" || strings.Contains(m, "HASH") || strings.Contains(m, "KEY") {
+		return "", nil
+	}
+
+	var parts []*Partition
+	prevTo := ""
+	for i, bound := range bounds {
+		bound = strings.TrimSpace(bound)
+		p := &Partition{Name: names[i]}
+		var ok bool
+		switch {
+		case strings.EqualFold(bound, "DEFAULT"), strings.HasPrefix(strings.ToUpper(bound), "FOR VALUES WITH"):
+			continue // the default partition takes anything; hash partitions take everything
+		case rePartRange.MatchString(bound):
+			mm := rePartRange.FindStringSubmatch(bound)
+			p.From, ok = partitionLiteral(firstItem(mm[1]), fn)
+			if ok {
+				p.To, ok = partitionLiteral(firstItem(mm[2]), fn)
+			}
+		case rePartList.MatchString(bound):
+			ok = true
+			for _, item := range splitItems(rePartList.FindStringSubmatch(bound)[1]) {
+				v, vok := partitionLiteral(item, fn)
+				ok = ok && vok
+				p.Values = append(p.Values, v)
+			}
+		case strings.Contains(m, "LIST"):
+			ok = true
+			for _, item := range splitItems(bound) {
+				v, vok := partitionLiteral(item, fn)
+				ok = ok && vok
+				p.Values = append(p.Values, v)
+			}
+		default: // RANGE upper bound only (VALUES LESS THAN)
+			p.From = prevTo
+			p.To, ok = partitionLiteral(firstItem(bound), fn)
+			prevTo = p.To
+		}
+		if !ok {
+			return "", nil
+		}
+		parts = append(parts, p)
+	}
+	if len(parts) == 0 {
+		return "", nil
+	}
+	return column, parts
+}
+
+// parsePartitionKey extracts the key column (first one for multi-column keys) and
+// the function around it, e.g. "to_days(`payment_date`)" → ("payment_date", "to_days").
+func parsePartitionKey(expr string) (string, string) {
+	expr = strings.TrimSpace(firstItem(expr))
+	fn := ""
+	if m := rePartKeyFunc.FindStringSubmatch(expr); m != nil {
+		fn, expr = strings.ToLower(m[1]), strings.TrimSpace(m[2])
+	}
+	return strings.Trim(expr, "`\"[] "), fn
+}
+
+// partitionLiteral normalizes one bound value. MAXVALUE / MINVALUE become "" (unbounded).
+// The second result is false when a key function can't be mapped back to column values.
+func partitionLiteral(s, fn string) (string, bool) {
+	s = strings.TrimSpace(s)
+	up := strings.ToUpper(s)
+	if up == "MAXVALUE" || up == "MINVALUE" {
+		return "", true
+	}
+	if m := rePartQuoted.FindStringSubmatch(s); m != nil {
+		// '2022-01-01', TO_DATE(' 2022-01-01 00:00:00', 'SYYYY-MM-DD ...'), TIMESTAMP' 2022-01-01 00:00:00'
+		s = strings.TrimSpace(m[1])
+	} else {
+		s = strings.TrimSpace(rePartCast.ReplaceAllString(s, ""))
+	}
+
+	switch fn {
+	case "":
+		return s, true
+	case "to_days":
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return "", false
+		}
+		return time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, n-719528).Format("2006-01-02"), true // TO_DAYS('1970-01-01') = 719528
+	case "year":
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return "", false
+		}
+		return fmt.Sprintf("%04d-01-01", n), true
+	case "unix_timestamp":
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return "", false
+		}
+		return time.Unix(n, 0).Format("2006-01-02 15:04:05"), true
+	}
+	return "", false
+}
+
+// splitItems splits a comma-separated value list, ignoring commas inside quotes or parentheses.
+func splitItems(s string) []string {
+	var items []string
+	depth, quoted, start := 0, false, 0
+	for i, r := range s {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			items = append(items, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(items, strings.TrimSpace(s[start:]))
+}
+
+func firstItem(s string) string {
+	return splitItems(s)[0]
+}
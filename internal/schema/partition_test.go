@@ -0,0 +1,82 @@
+package schema_test
+
+import (
+	"db-pump/internal/schema"
+	"testing"
+)
+
+func TestBuildPartitions(t *testing.T) {
+	cases := []struct {
+		name      string
+		method    string
+		key       string
+		bounds    []string
+		wantKey   string
+		wantFrom  []string
+		wantTo    []string
+		wantValue string // 첫 파티션의 첫 LIST 값
+	}{
+		{
+			name:   "postgres range",
+			method: "RANGE", key: "payment_date",
+			bounds: []string{
+				"FOR VALUES FROM ('2022-01-01 00:00:00+00') TO ('2022-02-01 00:00:00+00')",
+				"FOR VALUES FROM ('2022-02-01 00:00:00+00') TO (MAXVALUE)",
+				"DEFAULT",
+			},
+			wantKey:  "payment_date",
+			wantFrom: []string{"2022-01-01 00:00:00+00", "2022-02-01 00:00:00+00"},
+			wantTo:   []string{"2022-02-01 00:00:00+00", ""},
+		},
+		{
+			name:   "mysql to_days",
+			method: "RANGE", key: "to_days(`payment_date`)",
+			bounds:   []string{"738521", "738552", "MAXVALUE"}, // 2022-01-01, 2022-02-01
+			wantKey:  "payment_date",
+			wantFrom: []string{"", "2022-01-01", "2022-02-01"},
+			wantTo:   []string{"2022-01-01", "2022-02-01", ""},
+		},
+		{
+			name:   "oracle high value",
+			method: "RANGE", key: "ORDER_DATE",
+			bounds:   []string{"TO_DATE(' 2023-01-01 00:00:00', 'SYYYY-MM-DD HH24:MI:SS', 'NLS_CALENDAR=GREGORIAN')"},
+			wantKey:  "ORDER_DATE",
+			wantFrom: []string{""},
+			wantTo:   []string{"2023-01-01 00:00:00"},
+		},
+		{
+			name:   "list",
+			method: "LIST COLUMNS", key: "`region`",
+			bounds:    []string{"'KR','JP'", "'US'"},
+			wantKey:   "region",
+			wantFrom:  []string{"", ""},
+			wantTo:    []string{"", ""},
+			wantValue: "KR",
+		},
+		{
+			name:   "hash",
+			method: "HASH", key: "`id`",
+			bounds: []string{"", ""},
+		},
+	}
+
+	for _, c := range cases {
+		names := make([]string, len(c.bounds))
+		for i := range names {
+			names[i] = "p" + string(rune('0'+i))
+		}
+		key, parts := schema.BuildPartitions(c.method, c.key, names, c.bounds)
+		if key != c.wantKey || len(parts) != len(c.wantFrom) {
+			t.Errorf("%s: key %q with %d partitions, want %q with %d", c.name, key, len(parts), c.wantKey, len(c.wantFrom))
+			continue
+		}
+		for i, p := range parts {
+			if p.From != c.wantFrom[i] || p.To != c.wantTo[i] {
+				t.Errorf("%s: partition %d = [%q, %q), want [%q, %q)", c.name, i, p.From, p.To, c.wantFrom[i], c.wantTo[i])
+			}
+		}
+		if c.wantValue != "" && (len(parts[0].Values) == 0 || parts[0].Values[0] != c.wantValue) {
+			t.Errorf("%s: first LIST values %v, want %s first", c.name, parts[0].Values, c.wantValue)
+		}
+	}
+}
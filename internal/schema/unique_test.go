@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"testing"
+
+	"db-pump/internal/dialect"
+)
+
+// uniqueKeyRows is the (table, index, column) result of the "fakeunique" driver.
+var uniqueKeyRows [][]driver.Value
+
+func init() { sql.Register("fakeunique", fakeDriver{}) }
+
+type fakeDriver struct{}
+type fakeConn struct{}
+type fakeStmt struct{}
+type fakeRows struct{ rows [][]driver.Value }
+
+func (fakeDriver) Open(string) (driver.Conn, error)         { return fakeConn{}, nil }
+func (fakeConn) Prepare(string) (driver.Stmt, error)        { return fakeStmt{}, nil }
+func (fakeConn) Close() error                               { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                  { return nil, fmt.Errorf("read only") }
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, fmt.Errorf("read only") }
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: uniqueKeyRows}, nil
+}
+func (r *fakeRows) Columns() []string { return []string{"table_name", "index_name", "column_name"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestApplyUniqueKeys(t *testing.T) {
+	// 컬럼 조회가 복합 키의 컬럼을 각각 UNIQUE로 표시한 상태
+	email := &Column{Name: "email", IsUnique: true}
+	tenant := &Column{Name: "tenant_id", IsUnique: true}
+	code := &Column{Name: "code", IsUnique: true}
+	tableMap := map[string]*Table{"USERS": {Name: "users", Columns: []*Column{email, tenant, code}}}
+
+	uniqueKeyRows = [][]driver.Value{
+		{"users", "uq_email", "email"},
+		{"users", "uq_tenant_code", "tenant_id"},
+		{"users", "uq_tenant_code", "code"},
+		{"other", "uq_other", "x"}, // 분석 대상이 아닌 테이블
+	}
+	db, _ := sql.Open("fakeunique", "")
+	defer db.Close()
+	if err := applyUniqueKeys(db, dialect.GetDialect("postgres"), "public", tableMap); err != nil {
+		t.Fatal(err)
+	}
+
+	if !email.IsUnique || tenant.IsUnique || code.IsUnique {
+		t.Errorf("IsUnique: email=%v tenant_id=%v code=%v, want only email", email.IsUnique, tenant.IsUnique, code.IsUnique)
+	}
+	keys := tableMap["USERS"].UniqueKeys
+	if len(keys) != 2 || len(keys[1]) != 2 || keys[1][0] != "tenant_id" || keys[1][1] != "code" {
+		t.Errorf("UniqueKeys = %v, want [[email] [tenant_id code]]", keys)
+	}
+}